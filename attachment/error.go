@@ -0,0 +1,13 @@
+package attachment
+
+import "errors"
+
+var (
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrInvalidAttachment  = errors.New("invalid attachment")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrTaskNotFound       = errors.New("task not found")
+	ErrForbidden          = errors.New("role does not allow the action")
+	ErrFileTooLarge       = errors.New("file is larger than the size limit")
+	ErrQuotaExceeded      = errors.New("file would exceed the storage quota")
+)
@@ -0,0 +1,224 @@
+package attachment
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// HandlerImp implements Handler.
+type HandlerImp struct {
+	Service Service
+}
+
+// handleInvalidMethod will respond each time a request uses the wrong method.
+func (h *HandlerImp) handleInvalidMethod(w http.ResponseWriter) {
+	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+}
+
+// handleServerError will respond each time there is a server error.
+func (h *HandlerImp) handleServerError(w http.ResponseWriter) {
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+// handleInvalidToken will respond each time there is an invalid token.
+func (h *HandlerImp) handleInvalidToken(w http.ResponseWriter) {
+	http.Error(w, "Invalid token", http.StatusUnauthorized)
+}
+
+// handleInvalidId will respond each time an id is not a valid uuid.
+func (h *HandlerImp) handleInvalidId(w http.ResponseWriter) {
+	http.Error(w, "Invalid id", http.StatusBadRequest)
+}
+
+// handleError will respond to the errors returned by the service.
+func (h *HandlerImp) handleError(w http.ResponseWriter, err error, source string) {
+	switch {
+	case errors.Is(err, ErrInvalidAttachment):
+		http.Error(w, "Invalid attachment", http.StatusBadRequest)
+	case errors.Is(err, ErrFileTooLarge), errors.Is(err, ErrQuotaExceeded):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, ErrAttachmentNotFound):
+		http.Error(w, "Attachment not found", http.StatusNotFound)
+	case errors.Is(err, ErrTaskNotFound):
+		http.Error(w, "Task not found", http.StatusNotFound)
+	case errors.Is(err, ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case errors.Is(err, ErrInvalidToken):
+		h.handleInvalidToken(w)
+	default:
+		log.Printf("Error in attachment-HandlerImp-%s: %v", source, err)
+		h.handleServerError(w)
+	}
+}
+
+// HandleGet will handle get requests and send the attachments of the task in the task query parameter.
+func (h *HandlerImp) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	taskId, err := uuid.Parse(r.URL.Query().Get("task"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	attachments, err := h.Service.GetAttachments(&token, &taskId)
+	if err != nil {
+		h.handleError(w, err, "HandleGet")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(attachments)
+	if err != nil {
+		log.Printf("Error in attachment-HandlerImp-HandleGet: %v", err)
+	}
+}
+
+// HandlePost will handle multipart post requests uploading the file part to the task in the task query parameter.
+// The file is streamed to the storage while the request is read, it is never kept in memory.
+func (h *HandlerImp) HandlePost(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	taskId, err := uuid.Parse(r.URL.Query().Get("task"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.handleError(w, ErrInvalidAttachment, "HandlePost")
+		return
+	}
+
+	var upload *Upload
+	for upload == nil {
+		part, err := reader.NextPart()
+		if err != nil {
+			h.handleError(w, ErrInvalidAttachment, "HandlePost")
+			return
+		}
+
+		if part.FormName() == "file" {
+			upload = &Upload{
+				Name:        part.FileName(),
+				ContentType: part.Header.Get("Content-Type"),
+				Content:     part,
+			}
+		}
+	}
+
+	attachment, err := h.Service.AddAttachment(&token, &taskId, upload)
+	if err != nil {
+		h.handleError(w, err, "HandlePost")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(attachment)
+	if err != nil {
+		log.Printf("Error in attachment-HandlerImp-HandlePost: %v", err)
+	}
+}
+
+// HandleDownload will handle get requests and send the file of the attachment in the id query parameter.
+func (h *HandlerImp) HandleDownload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	attachment, content, err := h.Service.OpenAttachment(&token, &id)
+	if err != nil {
+		h.handleError(w, err, "HandleDownload")
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Name}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", strconv.Quote(attachment.Checksum))
+	w.WriteHeader(http.StatusOK)
+
+	_, err = io.Copy(w, content)
+	if err != nil {
+		log.Printf("Error in attachment-HandlerImp-HandleDownload: %v", err)
+	}
+}
+
+// HandleDelete will handle delete requests for deleting an attachment.
+func (h *HandlerImp) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	err = h.Service.DeleteAttachment(&token, &id)
+	if err != nil {
+		h.handleError(w, err, "HandleDelete")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// NewHandlerImp will create a new handler with a service.
+func NewHandlerImp(service Service) *HandlerImp {
+	return &HandlerImp{
+		Service: service,
+	}
+}
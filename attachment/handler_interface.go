@@ -0,0 +1,18 @@
+package attachment
+
+import "net/http"
+
+// Handler defines methods for an attachment handler.
+type Handler interface {
+	// HandleGet will handle listing the attachments of a task.
+	HandleGet(w http.ResponseWriter, r *http.Request)
+
+	// HandlePost will handle uploading an attachment.
+	HandlePost(w http.ResponseWriter, r *http.Request)
+
+	// HandleDownload will handle downloading an attachment.
+	HandleDownload(w http.ResponseWriter, r *http.Request)
+
+	// HandleDelete will handle deleting an attachment.
+	HandleDelete(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,68 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalStorage is an implementation of Storage keeping every blob in a file of a directory.
+type LocalStorage struct {
+	Directory string
+}
+
+// path will return the file holding a blob, the key is reduced to its base name so it cannot leave the directory.
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.Directory, filepath.Base(key))
+}
+
+// Save will write the content to a temporary file and rename it once it is complete,
+// so a failed upload never leaves a partial blob.
+func (s *LocalStorage) Save(key string, content io.Reader) (int64, error) {
+	file, err := os.CreateTemp(s.Directory, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, content)
+	if err != nil {
+		file.Close()
+		return written, err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return written, err
+	}
+	return written, os.Rename(file.Name(), s.path(key))
+}
+
+// Open will open the file of a blob.
+func (s *LocalStorage) Open(key string) (io.ReadCloser, error) {
+	file, err := os.Open(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrAttachmentNotFound
+	}
+	return file, err
+}
+
+// Delete will delete the file of a blob.
+func (s *LocalStorage) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// NewLocalStorage will create a storage in a directory, creating the directory if it is missing.
+func NewLocalStorage(directory string) (*LocalStorage, error) {
+	err := os.MkdirAll(directory, 0o750)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalStorage{Directory: directory}, nil
+}
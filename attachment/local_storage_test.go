@@ -0,0 +1,61 @@
+package attachment
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	storage, err := NewLocalStorage(filepath.Join(t.TempDir(), "blobs"))
+	if err != nil {
+		t.Fatalf("NewLocalStorage returned %v", err)
+	}
+
+	written, err := storage.Save("report.pdf", strings.NewReader("quarterly report"))
+	if err != nil || written != 16 {
+		t.Fatalf("Save = %d, %v, want 16", written, err)
+	}
+
+	file, err := storage.Open("report.pdf")
+	if err != nil {
+		t.Fatalf("Open returned %v", err)
+	}
+	content, err := io.ReadAll(file)
+	file.Close()
+	if err != nil || string(content) != "quarterly report" {
+		t.Errorf("content = %q, %v, want %q", content, err, "quarterly report")
+	}
+
+	entries, err := os.ReadDir(storage.Directory)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("directory holds %d entries, %v, want only the blob", len(entries), err)
+	}
+
+	err = storage.Delete("report.pdf")
+	if err != nil {
+		t.Fatalf("Delete returned %v", err)
+	}
+	_, err = storage.Open("report.pdf")
+	if !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Open after Delete returned %v, want %v", err, ErrAttachmentNotFound)
+	}
+	err = storage.Delete("report.pdf")
+	if err != nil {
+		t.Errorf("Delete of a missing blob returned %v", err)
+	}
+}
+
+func TestLocalStoragePath(t *testing.T) {
+	storage := &LocalStorage{Directory: "/var/blobs"}
+
+	for _, key := range []string{"blob", "../blob", "/etc/../../blob", "a/b/blob"} {
+		got := storage.path(key)
+		if got != "/var/blobs/blob" {
+			t.Errorf("path(%q) = %q, want /var/blobs/blob", key, got)
+		}
+	}
+}
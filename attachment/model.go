@@ -0,0 +1,31 @@
+package attachment
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultContentType is used when an upload does not declare its content type.
+const DefaultContentType = "application/octet-stream"
+
+// Attachment is the metadata of a file uploaded to a task, the bytes are kept in a Storage.
+type Attachment struct {
+	Id          uuid.UUID `json:"id"`
+	TaskId      uuid.UUID `json:"taskId"`
+	UploaderId  uuid.UUID `json:"uploaderId"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"contentType"`
+	// Checksum is the hex encoded SHA-256 of the file.
+	Checksum    string    `json:"checksum"`
+	DateCreated time.Time `json:"dateCreated"`
+}
+
+// Upload is a file being uploaded, its content is read only once.
+type Upload struct {
+	Name        string
+	ContentType string
+	Content     io.Reader
+}
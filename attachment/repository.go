@@ -0,0 +1,198 @@
+package attachment
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// PostgresRepository is an implementation of Repository.
+type PostgresRepository struct {
+	database *sql.DB
+}
+
+// attachmentColumns are the columns selected for every attachment.
+const attachmentColumns = "id, task_id, uploader_id, name, size, content_type, checksum, date_created"
+
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAttachment will scan a row selected with attachmentColumns.
+func scanAttachment(row rowScanner) (*Attachment, error) {
+	var attachment Attachment
+	err := row.Scan(&attachment.Id, &attachment.TaskId, &attachment.UploaderId, &attachment.Name, &attachment.Size,
+		&attachment.ContentType, &attachment.Checksum, &attachment.DateCreated)
+	if err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetAttachments will get the attachments of a task ordered by upload date.
+func (r *PostgresRepository) GetAttachments(taskId *uuid.UUID) ([]Attachment, error) {
+	query := "SELECT " + attachmentColumns + " FROM attachments WHERE task_id = $1 ORDER BY date_created, id"
+	log.Printf("Executing query in attachment-PostgresRepository-GetAttachments: %s | Parameters %s", query, taskId)
+
+	rows, err := r.database.Query(query, *taskId)
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-GetAttachments: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := make([]Attachment, 0)
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			log.Printf("Error in attachment-PostgresRepository-GetAttachments: %v", err)
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-GetAttachments: %v", err)
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// GetAttachment will get an attachment by its id, attachments of permanently deleted tasks are not returned.
+func (r *PostgresRepository) GetAttachment(attachmentId *uuid.UUID) (*Attachment, error) {
+	query := "SELECT " + attachmentColumns + " FROM attachments WHERE id = $1 AND task_id IS NOT NULL"
+	log.Printf("Executing query in attachment-PostgresRepository-GetAttachment: %s | Parameters %s", query, attachmentId)
+
+	attachment, err := scanAttachment(r.database.QueryRow(query, *attachmentId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAttachmentNotFound
+	} else if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-GetAttachment: %v", err)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// GetUsage will get the total size of the files uploaded by a user.
+func (r *PostgresRepository) GetUsage(userId *uuid.UUID) (int64, error) {
+	query := "SELECT COALESCE(SUM(size), 0) FROM attachments WHERE uploader_id = $1"
+	log.Printf("Executing query in attachment-PostgresRepository-GetUsage: %s | Parameters %s", query, userId)
+
+	var usage int64
+	err := r.database.QueryRow(query, *userId).Scan(&usage)
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-GetUsage: %v", err)
+		return 0, err
+	}
+	return usage, nil
+}
+
+// AddAttachment will add the metadata of an uploaded file unless the files of the uploader would go over a quota.
+// The row of the uploader is locked first so concurrent uploads can not go over the quota together.
+func (r *PostgresRepository) AddAttachment(attachment *Attachment, quota int64) error {
+	log.Printf("Executing query in attachment-PostgresRepository-AddAttachment: BEGIN")
+	tx, err := r.database.Begin()
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-AddAttachment: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "SELECT id FROM users WHERE id = $1 FOR UPDATE"
+	log.Printf("Executing query in attachment-PostgresRepository-AddAttachment: %s | Parameters %s", query, attachment.UploaderId)
+	_, err = tx.Exec(query, attachment.UploaderId)
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-AddAttachment: %v", err)
+		return err
+	}
+
+	query = "INSERT INTO attachments(id, task_id, uploader_id, name, size, content_type, checksum, date_created) " +
+		"SELECT $1, $2, $3, $4, $5, $6, $7, $8 WHERE (SELECT COALESCE(SUM(size), 0) FROM attachments WHERE uploader_id = $3) + $5 <= $9"
+	log.Printf("Executing query in attachment-PostgresRepository-AddAttachment: %s | Parameters %s, %s, %s, %s, %d, %s, %s, %s, %d", query,
+		attachment.Id, attachment.TaskId, attachment.UploaderId, attachment.Name, attachment.Size, attachment.ContentType, attachment.Checksum, attachment.DateCreated, quota)
+	result, err := tx.Exec(query, attachment.Id, attachment.TaskId, attachment.UploaderId, attachment.Name, attachment.Size,
+		attachment.ContentType, attachment.Checksum, attachment.DateCreated, quota)
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-AddAttachment: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-AddAttachment: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrQuotaExceeded
+	}
+
+	log.Printf("Executing query in attachment-PostgresRepository-AddAttachment: COMMIT")
+	err = tx.Commit()
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-AddAttachment: %v", err)
+	}
+	return err
+}
+
+// DeleteAttachment will delete the metadata of a file.
+func (r *PostgresRepository) DeleteAttachment(attachmentId *uuid.UUID) error {
+	query := "DELETE FROM attachments WHERE id = $1"
+	log.Printf("Executing query in attachment-PostgresRepository-DeleteAttachment: %s | Parameters %s", query, attachmentId)
+
+	result, err := r.database.Exec(query, *attachmentId)
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-DeleteAttachment: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-DeleteAttachment: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// GetOrphans will get the ids of the attachments whose task was permanently deleted.
+// Deleting a task sets the task of its attachments to null so their files can be removed too.
+func (r *PostgresRepository) GetOrphans() ([]uuid.UUID, error) {
+	query := "SELECT id FROM attachments WHERE task_id IS NULL"
+	log.Printf("Executing query in attachment-PostgresRepository-GetOrphans: %s", query)
+
+	rows, err := r.database.Query(query)
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-GetOrphans: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		err = rows.Scan(&id)
+		if err != nil {
+			log.Printf("Error in attachment-PostgresRepository-GetOrphans: %v", err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in attachment-PostgresRepository-GetOrphans: %v", err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+// NewPostgresRepository will create a new repository with a connection.
+func NewPostgresRepository(database *sql.DB) *PostgresRepository {
+	return &PostgresRepository{database}
+}
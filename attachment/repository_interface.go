@@ -0,0 +1,24 @@
+package attachment
+
+import "github.com/google/uuid"
+
+// Repository defines the methods for an attachment repository.
+type Repository interface {
+	// GetAttachments will get the attachments of a task ordered by upload date.
+	GetAttachments(*uuid.UUID) ([]Attachment, error)
+
+	// GetAttachment will get an attachment by its id.
+	GetAttachment(*uuid.UUID) (*Attachment, error)
+
+	// GetUsage will get the total size of the files uploaded by a user.
+	GetUsage(*uuid.UUID) (int64, error)
+
+	// AddAttachment will add the metadata of an uploaded file unless the files of the uploader would go over a quota.
+	AddAttachment(*Attachment, int64) error
+
+	// DeleteAttachment will delete the metadata of a file.
+	DeleteAttachment(*uuid.UUID) error
+
+	// GetOrphans will get the ids of the attachments whose task was permanently deleted.
+	GetOrphans() ([]uuid.UUID, error)
+}
@@ -0,0 +1,226 @@
+package attachment
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"path/filepath"
+	"task-server/middleware"
+	"task-server/task"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskAccess is used to find the role of a user on a task.
+type TaskAccess interface {
+	// GetTaskAccess will get the owner of a task and the highest role a user has on it.
+	GetTaskAccess(*uuid.UUID, *uuid.UUID) (*uuid.UUID, task.Role, error)
+}
+
+// ServiceImp is an implementation of Service.
+type ServiceImp struct {
+	Repository    Repository
+	Storage       Storage
+	Tasks         TaskAccess
+	Authenticator middleware.Authenticator
+	// MaxFileSize is the biggest file in bytes that can be uploaded.
+	MaxFileSize int64
+	// UserQuota is how many bytes the files uploaded by a user can take in total.
+	UserQuota int64
+}
+
+// checkRole will check that a user can see a task and, if write is true, change it.
+func (s *ServiceImp) checkRole(userId *uuid.UUID, taskId *uuid.UUID, write bool) (task.Role, error) {
+	_, role, err := s.Tasks.GetTaskAccess(taskId, userId)
+	if errors.Is(err, task.ErrTaskNotFound) {
+		return "", ErrTaskNotFound
+	} else if err != nil {
+		return "", err
+	}
+
+	if write && role == task.RoleViewer {
+		return "", ErrForbidden
+	}
+	return role, nil
+}
+
+// GetAttachments will return the attachments of a task, every user the task is shared with can see them.
+func (s *ServiceImp) GetAttachments(tokenString *string, taskId *uuid.UUID) ([]Attachment, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-GetAttachments: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	_, err = s.checkRole(id, taskId, false)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-GetAttachments: %v", err)
+		return nil, err
+	}
+
+	attachments, err := s.Repository.GetAttachments(taskId)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-GetAttachments: %v", err)
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// AddAttachment will stream an upload into the storage while computing its size and checksum.
+// The upload is stopped as soon as it goes over the file size limit or the remaining quota of the user.
+// The quota is checked again when the file is added, concurrent uploads may have used it up in the meantime.
+func (s *ServiceImp) AddAttachment(tokenString *string, taskId *uuid.UUID, upload *Upload) (*Attachment, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-AddAttachment: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	name := filepath.Base(filepath.Clean("/" + upload.Name))
+	if name == "/" || name == "." {
+		return nil, ErrInvalidAttachment
+	}
+
+	_, err = s.checkRole(id, taskId, true)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-AddAttachment: %v", err)
+		return nil, err
+	}
+
+	usage, err := s.Repository.GetUsage(id)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-AddAttachment: %v", err)
+		return nil, err
+	}
+
+	limit, limitErr := s.MaxFileSize, ErrFileTooLarge
+	if remaining := s.UserQuota - usage; remaining < limit {
+		limit, limitErr = max(remaining, 0), ErrQuotaExceeded
+	}
+
+	attachment := &Attachment{
+		Id:          uuid.New(),
+		TaskId:      *taskId,
+		UploaderId:  *id,
+		Name:        name,
+		ContentType: upload.ContentType,
+		DateCreated: time.Now().UTC(),
+	}
+	if attachment.ContentType == "" {
+		attachment.ContentType = DefaultContentType
+	}
+
+	// One byte more than the limit is read so an oversized upload can be told apart from one of exactly the limit.
+	hash := sha256.New()
+	content := io.TeeReader(io.LimitReader(upload.Content, limit+1), hash)
+	attachment.Size, err = s.Storage.Save(attachment.Id.String(), content)
+	if err == nil && attachment.Size > limit {
+		err = limitErr
+	}
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-AddAttachment: %v", err)
+		s.deleteFile(&attachment.Id)
+		return nil, err
+	}
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	err = s.Repository.AddAttachment(attachment, s.UserQuota)
+	if errors.Is(err, ErrQuotaExceeded) {
+		s.deleteFile(&attachment.Id)
+		return nil, err
+	} else if err != nil {
+		log.Printf("Error in attachment-ServiceImp-AddAttachment: %v", err)
+		s.deleteFile(&attachment.Id)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// OpenAttachment will return an attachment with its content, every user the task is shared with can download it.
+func (s *ServiceImp) OpenAttachment(tokenString *string, attachmentId *uuid.UUID) (*Attachment, io.ReadCloser, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-OpenAttachment: %v", err)
+		return nil, nil, ErrInvalidToken
+	}
+
+	attachment, err := s.Repository.GetAttachment(attachmentId)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-OpenAttachment: %v", err)
+		return nil, nil, err
+	}
+
+	_, err = s.checkRole(id, &attachment.TaskId, false)
+	if errors.Is(err, ErrTaskNotFound) {
+		return nil, nil, ErrAttachmentNotFound
+	} else if err != nil {
+		log.Printf("Error in attachment-ServiceImp-OpenAttachment: %v", err)
+		return nil, nil, err
+	}
+
+	content, err := s.Storage.Open(attachment.Id.String())
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-OpenAttachment: %v", err)
+		return nil, nil, err
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment will delete an attachment and its file, it can be done by its uploader and by the owner and the admins of the task.
+func (s *ServiceImp) DeleteAttachment(tokenString *string, attachmentId *uuid.UUID) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-DeleteAttachment: %v", err)
+		return ErrInvalidToken
+	}
+
+	attachment, err := s.Repository.GetAttachment(attachmentId)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-DeleteAttachment: %v", err)
+		return err
+	}
+
+	role, err := s.checkRole(id, &attachment.TaskId, true)
+	if errors.Is(err, ErrTaskNotFound) {
+		return ErrAttachmentNotFound
+	} else if err != nil {
+		log.Printf("Error in attachment-ServiceImp-DeleteAttachment: %v", err)
+		return err
+	}
+
+	if attachment.UploaderId != *id && role != task.RoleOwner && role != task.RoleAdmin {
+		return ErrForbidden
+	}
+
+	err = s.Repository.DeleteAttachment(attachmentId)
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-DeleteAttachment: %v", err)
+		return err
+	}
+
+	s.deleteFile(attachmentId)
+	return nil
+}
+
+// deleteFile will delete the file of an attachment, a failure only leaves an unused file behind so it is just logged.
+func (s *ServiceImp) deleteFile(attachmentId *uuid.UUID) {
+	err := s.Storage.Delete(attachmentId.String())
+	if err != nil {
+		log.Printf("Error in attachment-ServiceImp-deleteFile: %v", err)
+	}
+}
+
+// NewServiceImp will create a new service with a repository, storage, task access, authenticator and size limits.
+func NewServiceImp(repository Repository, storage Storage, tasks TaskAccess, authenticator middleware.Authenticator, maxFileSize int64, userQuota int64) *ServiceImp {
+	return &ServiceImp{
+		Repository:    repository,
+		Storage:       storage,
+		Tasks:         tasks,
+		Authenticator: authenticator,
+		MaxFileSize:   maxFileSize,
+		UserQuota:     userQuota,
+	}
+}
@@ -0,0 +1,22 @@
+package attachment
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Service defines the methods for an attachment service.
+type Service interface {
+	// GetAttachments will return the attachments of a task.
+	GetAttachments(*string, *uuid.UUID) ([]Attachment, error)
+
+	// AddAttachment will stream an upload into the storage and attach it to a task.
+	AddAttachment(*string, *uuid.UUID, *Upload) (*Attachment, error)
+
+	// OpenAttachment will return an attachment with its content, the caller must close the content.
+	OpenAttachment(*string, *uuid.UUID) (*Attachment, io.ReadCloser, error)
+
+	// DeleteAttachment will delete an attachment and its file.
+	DeleteAttachment(*string, *uuid.UUID) error
+}
@@ -0,0 +1,15 @@
+package attachment
+
+import "io"
+
+// Storage keeps the bytes of the attachments under a key.
+type Storage interface {
+	// Save will stream the content into the blob with a key and return how many bytes were written.
+	Save(string, io.Reader) (int64, error)
+
+	// Open will open the blob with a key for reading.
+	Open(string) (io.ReadCloser, error)
+
+	// Delete will delete the blob with a key, deleting a missing blob is not an error.
+	Delete(string) error
+}
@@ -0,0 +1,63 @@
+package attachment
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Sweeper will delete the files of the attachments whose task was permanently deleted.
+type Sweeper struct {
+	Repository Repository
+	Storage    Storage
+	// Interval is how often the orphaned attachments are checked.
+	Interval time.Duration
+}
+
+// sweep will delete the files and then the metadata of the orphaned attachments.
+func (s *Sweeper) sweep() {
+	ids, err := s.Repository.GetOrphans()
+	if err != nil {
+		log.Printf("Error in attachment-Sweeper-sweep: %v", err)
+		return
+	}
+
+	for _, id := range ids {
+		err = s.Storage.Delete(id.String())
+		if err == nil {
+			err = s.Repository.DeleteAttachment(&id)
+		}
+		if err != nil {
+			log.Printf("Error in attachment-Sweeper-sweep: %v", err)
+		}
+	}
+
+	if len(ids) > 0 {
+		log.Printf("Swept %d orphaned attachments", len(ids))
+	}
+}
+
+// Run will sweep the orphaned attachments on every interval until the context is done.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.sweep()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewSweeper will create a new sweeper with a repository, storage and check interval.
+func NewSweeper(repository Repository, storage Storage, interval time.Duration) Sweeper {
+	return Sweeper{
+		Repository: repository,
+		Storage:    storage,
+		Interval:   interval,
+	}
+}
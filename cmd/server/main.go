@@ -8,16 +8,77 @@ import (
 
 func main() {
 	config.LoadEnvironmentFiles("../../config/.env")
-	userHandler, taskHandler := config.CreateHandlers()
+	userHandler, taskHandler, commentHandler, attachmentHandler, reminderHandler, timeEntryHandler := config.CreateHandlers()
 
 	mux := http.NewServeMux()
 	mux.Handle("/users/login", http.HandlerFunc(userHandler.HandleLogin))
 	mux.Handle("/users/register", http.HandlerFunc(userHandler.HandleRegister))
 	mux.Handle("/users/refresh", http.HandlerFunc(userHandler.HandleRefresh))
 	mux.Handle("/tasks/get", http.HandlerFunc(taskHandler.HandleGet))
+	mux.Handle("/tasks/search", http.HandlerFunc(taskHandler.HandleSearch))
 	mux.Handle("/tasks/add", http.HandlerFunc(taskHandler.HandlePost))
 	mux.Handle("/tasks/update", http.HandlerFunc(taskHandler.HandlePut))
+	mux.Handle("/tasks/bulk", http.HandlerFunc(taskHandler.HandleBulk))
+	mux.Handle("/tasks/export.csv", http.HandlerFunc(taskHandler.HandleExport))
+	mux.Handle("/tasks/import", http.HandlerFunc(taskHandler.HandleImport))
+	mux.Handle("/tasks/import.ics", http.HandlerFunc(taskHandler.HandleImportCalendar))
+	mux.Handle("/tasks/export.txt", http.HandlerFunc(taskHandler.HandleExportTodoTxt))
+	mux.Handle("/tasks/import.txt", http.HandlerFunc(taskHandler.HandleImportTodoTxt))
 	mux.Handle("/tasks/delete", http.HandlerFunc(taskHandler.HandleDelete))
+	mux.Handle("/tasks/complete", http.HandlerFunc(taskHandler.HandleComplete))
+	mux.Handle("/tasks/uncomplete", http.HandlerFunc(taskHandler.HandleUncomplete))
+	mux.Handle("/tasks/trash", http.HandlerFunc(taskHandler.HandleGetTrash))
+	mux.Handle("/tasks/trash/empty", http.HandlerFunc(taskHandler.HandleEmptyTrash))
+	mux.Handle("/tasks/restore", http.HandlerFunc(taskHandler.HandleRestore))
+	mux.Handle("/tasks/recurrence", http.HandlerFunc(taskHandler.HandleRecurrence))
+	mux.Handle("/tasks/tree", http.HandlerFunc(taskHandler.HandleGetTree))
+	mux.Handle("/tasks/move", http.HandlerFunc(taskHandler.HandleMove))
+	mux.Handle("/tasks/rank", http.HandlerFunc(taskHandler.HandleRank))
+	mux.Handle("/tasks/list", http.HandlerFunc(taskHandler.HandleMoveToList))
+	mux.Handle("/tasks/tags/attach", http.HandlerFunc(taskHandler.HandleAttachTags))
+	mux.Handle("/tasks/tags/detach", http.HandlerFunc(taskHandler.HandleDetachTags))
+	mux.Handle("/tasks/dependencies", http.HandlerFunc(taskHandler.HandleGetBlockers))
+	mux.Handle("/tasks/dependencies/add", http.HandlerFunc(taskHandler.HandleAddDependency))
+	mux.Handle("/tasks/dependencies/remove", http.HandlerFunc(taskHandler.HandleRemoveDependency))
+	mux.Handle("/tasks/workload", http.HandlerFunc(taskHandler.HandleGetWorkload))
+	mux.Handle("/tasks/history", http.HandlerFunc(taskHandler.HandleGetHistory))
+	mux.Handle("/tasks/undo", http.HandlerFunc(taskHandler.HandleUndo))
+	mux.Handle("/tasks/{id}", http.HandlerFunc(taskHandler.HandlePatch))
+	mux.Handle("/activity", http.HandlerFunc(taskHandler.HandleGetActivity))
+	mux.Handle("/calendar/token", http.HandlerFunc(taskHandler.HandlePostFeedToken))
+	mux.Handle("/calendar/token/delete", http.HandlerFunc(taskHandler.HandleDeleteFeedToken))
+	mux.Handle("/calendar/feed/{token}", http.HandlerFunc(taskHandler.HandleCalendarFeed))
+	mux.Handle("/tags/get", http.HandlerFunc(taskHandler.HandleGetTags))
+	mux.Handle("/tags/add", http.HandlerFunc(taskHandler.HandlePostTag))
+	mux.Handle("/tags/update", http.HandlerFunc(taskHandler.HandlePutTag))
+	mux.Handle("/tags/delete", http.HandlerFunc(taskHandler.HandleDeleteTag))
+	mux.Handle("/lists/get", http.HandlerFunc(taskHandler.HandleGetLists))
+	mux.Handle("/lists/add", http.HandlerFunc(taskHandler.HandlePostList))
+	mux.Handle("/lists/update", http.HandlerFunc(taskHandler.HandlePutList))
+	mux.Handle("/lists/delete", http.HandlerFunc(taskHandler.HandleDeleteList))
+	mux.Handle("/shares/get", http.HandlerFunc(taskHandler.HandleGetShares))
+	mux.Handle("/shares/add", http.HandlerFunc(taskHandler.HandlePostShare))
+	mux.Handle("/shares/update", http.HandlerFunc(taskHandler.HandlePutShare))
+	mux.Handle("/shares/delete", http.HandlerFunc(taskHandler.HandleDeleteShare))
+	mux.Handle("/comments/get", http.HandlerFunc(commentHandler.HandleGet))
+	mux.Handle("/comments/add", http.HandlerFunc(commentHandler.HandlePost))
+	mux.Handle("/comments/update", http.HandlerFunc(commentHandler.HandlePut))
+	mux.Handle("/comments/delete", http.HandlerFunc(commentHandler.HandleDelete))
+	mux.Handle("/attachments/get", http.HandlerFunc(attachmentHandler.HandleGet))
+	mux.Handle("/attachments/add", http.HandlerFunc(attachmentHandler.HandlePost))
+	mux.Handle("/attachments/download", http.HandlerFunc(attachmentHandler.HandleDownload))
+	mux.Handle("/attachments/delete", http.HandlerFunc(attachmentHandler.HandleDelete))
+	mux.Handle("/reminders/get", http.HandlerFunc(reminderHandler.HandleGet))
+	mux.Handle("/reminders/add", http.HandlerFunc(reminderHandler.HandlePost))
+	mux.Handle("/reminders/delete", http.HandlerFunc(reminderHandler.HandleDelete))
+	mux.Handle("/time/entries", http.HandlerFunc(timeEntryHandler.HandleGet))
+	mux.Handle("/time/entries/add", http.HandlerFunc(timeEntryHandler.HandlePost))
+	mux.Handle("/time/entries/update", http.HandlerFunc(timeEntryHandler.HandlePut))
+	mux.Handle("/time/entries/delete", http.HandlerFunc(timeEntryHandler.HandleDelete))
+	mux.Handle("/time/timer", http.HandlerFunc(timeEntryHandler.HandleGetTimer))
+	mux.Handle("/time/timer/start", http.HandlerFunc(timeEntryHandler.HandleStartTimer))
+	mux.Handle("/time/timer/stop", http.HandlerFunc(timeEntryHandler.HandleStopTimer))
+	mux.Handle("/time/totals", http.HandlerFunc(timeEntryHandler.HandleGetTotals))
 
 	err := http.ListenAndServe(":8080", mux)
 	if err != nil {
@@ -0,0 +1,13 @@
+package comment
+
+import "errors"
+
+var (
+	ErrInvalidToken      = errors.New("invalid token")
+	ErrInvalidComment    = errors.New("invalid comment")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrCommentNotFound   = errors.New("comment not found")
+	ErrTaskNotFound      = errors.New("task not found")
+	ErrForbidden         = errors.New("only the author can change a comment")
+	ErrEditWindowExpired = errors.New("comment can no longer be edited")
+)
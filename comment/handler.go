@@ -0,0 +1,219 @@
+package comment
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// HandlerImp implements Handler.
+type HandlerImp struct {
+	Service Service
+}
+
+// handleInvalidMethod will respond each time a request uses the wrong method.
+func (h *HandlerImp) handleInvalidMethod(w http.ResponseWriter) {
+	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+}
+
+// handleInvalidJson will respond to any invalid json formats send to the server.
+func (h *HandlerImp) handleInvalidJson(w http.ResponseWriter) {
+	http.Error(w, "Invalid json format", http.StatusBadRequest)
+}
+
+// handleServerError will respond each time there is a server error.
+func (h *HandlerImp) handleServerError(w http.ResponseWriter) {
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+// handleInvalidToken will respond each time there is an invalid token.
+func (h *HandlerImp) handleInvalidToken(w http.ResponseWriter) {
+	http.Error(w, "Invalid token", http.StatusUnauthorized)
+}
+
+// handleInvalidId will respond each time an id is not a valid uuid.
+func (h *HandlerImp) handleInvalidId(w http.ResponseWriter) {
+	http.Error(w, "Invalid id", http.StatusBadRequest)
+}
+
+// handleError will respond to the errors returned by the service.
+func (h *HandlerImp) handleError(w http.ResponseWriter, err error, source string) {
+	switch {
+	case errors.Is(err, ErrInvalidComment):
+		http.Error(w, "Invalid comment", http.StatusBadRequest)
+	case errors.Is(err, ErrInvalidCursor):
+		http.Error(w, "Invalid cursor or limit", http.StatusBadRequest)
+	case errors.Is(err, ErrCommentNotFound):
+		http.Error(w, "Comment not found", http.StatusNotFound)
+	case errors.Is(err, ErrTaskNotFound):
+		http.Error(w, "Task not found", http.StatusNotFound)
+	case errors.Is(err, ErrForbidden), errors.Is(err, ErrEditWindowExpired):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, ErrInvalidToken):
+		h.handleInvalidToken(w)
+	default:
+		log.Printf("Error in comment-HandlerImp-%s: %v", source, err)
+		h.handleServerError(w)
+	}
+}
+
+// writeComment will respond with a comment.
+func (h *HandlerImp) writeComment(w http.ResponseWriter, comment *Comment) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(comment)
+	if err != nil {
+		log.Printf("Error in comment-HandlerImp-writeComment: %v", err)
+	}
+}
+
+// HandleGet will handle get requests and send a page of the comments of the task in the task query parameter.
+// The page size is read from the limit query parameter and the page position from the cursor query parameter.
+func (h *HandlerImp) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	taskId, err := uuid.Parse(r.URL.Query().Get("task"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	limit := DefaultLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			h.handleError(w, ErrInvalidCursor, "HandleGet")
+			return
+		}
+	}
+
+	var after *Cursor
+	if value := r.URL.Query().Get("cursor"); value != "" {
+		after, err = DecodeCursor(value)
+		if err != nil {
+			h.handleError(w, err, "HandleGet")
+			return
+		}
+	}
+
+	page, err := h.Service.GetComments(&token, &taskId, after, limit)
+	if err != nil {
+		h.handleError(w, err, "HandleGet")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		log.Printf("Error in comment-HandlerImp-HandleGet: %v", err)
+	}
+}
+
+// HandlePost will handle post requests for adding a comment.
+func (h *HandlerImp) HandlePost(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedComment NewComment
+	err = json.NewDecoder(r.Body).Decode(&receivedComment)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	comment, err := h.Service.AddComment(&token, &receivedComment)
+	if err != nil {
+		h.handleError(w, err, "HandlePost")
+		return
+	}
+
+	h.writeComment(w, comment)
+}
+
+// HandlePut will handle put requests for editing a comment.
+func (h *HandlerImp) HandlePut(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var update CommentUpdate
+	err = json.NewDecoder(r.Body).Decode(&update)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	comment, err := h.Service.UpdateComment(&token, &update)
+	if err != nil {
+		h.handleError(w, err, "HandlePut")
+		return
+	}
+
+	h.writeComment(w, comment)
+}
+
+// HandleDelete will handle delete requests for deleting a comment.
+func (h *HandlerImp) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	err = h.Service.DeleteComment(&token, &id)
+	if err != nil {
+		h.handleError(w, err, "HandleDelete")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// NewHandlerImp will create a new handler with a service.
+func NewHandlerImp(service Service) *HandlerImp {
+	return &HandlerImp{
+		Service: service,
+	}
+}
@@ -0,0 +1,18 @@
+package comment
+
+import "net/http"
+
+// Handler defines methods for a comment handler.
+type Handler interface {
+	// HandleGet will handle getting a page of the comments of a task.
+	HandleGet(w http.ResponseWriter, r *http.Request)
+
+	// HandlePost will handle adding a comment.
+	HandlePost(w http.ResponseWriter, r *http.Request)
+
+	// HandlePut will handle editing a comment.
+	HandlePut(w http.ResponseWriter, r *http.Request)
+
+	// HandleDelete will handle deleting a comment.
+	HandleDelete(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,77 @@
+package comment
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLimit is the page size used when the client does not specify one.
+	DefaultLimit = 50
+	// MaxLimit is the biggest page size a client can request.
+	MaxLimit = 200
+	// MaxBodyLength is the biggest number of characters a comment can have.
+	MaxBodyLength = 10000
+)
+
+// Comment is a message written by a user on a task.
+type Comment struct {
+	Id          uuid.UUID  `json:"id"`
+	TaskId      uuid.UUID  `json:"taskId"`
+	AuthorId    uuid.UUID  `json:"authorId"`
+	AuthorEmail string     `json:"authorEmail"`
+	Body        string     `json:"body"`
+	DateCreated time.Time  `json:"dateCreated"`
+	DateEdited  *time.Time `json:"dateEdited"`
+}
+
+// NewComment is a comment that will be added to a task.
+type NewComment struct {
+	TaskId uuid.UUID `json:"taskId"`
+	Body   string    `json:"body"`
+}
+
+// CommentUpdate is the body of a request editing a comment.
+type CommentUpdate struct {
+	Id   uuid.UUID `json:"id"`
+	Body string    `json:"body"`
+}
+
+// Page is a single page of the comments of a task.
+type Page struct {
+	Comments []Comment `json:"comments"`
+	Next     string    `json:"next,omitempty"`
+}
+
+// Cursor is the position of the last comment of a page.
+type Cursor struct {
+	DateCreated time.Time `json:"dc"`
+	Id          uuid.UUID `json:"id"`
+}
+
+// Encode will encode the cursor into an opaque string.
+func (c *Cursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor will decode a cursor created by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,143 @@
+package comment
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresRepository is an implementation of Repository.
+type PostgresRepository struct {
+	database *sql.DB
+}
+
+// commentColumns are the columns selected for every comment, c is the comments table and u the author.
+const commentColumns = "c.id, c.task_id, c.author_id, u.email, c.body, c.date_created, c.date_edited"
+
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanComment will scan a row selected with commentColumns.
+func scanComment(row rowScanner) (*Comment, error) {
+	var comment Comment
+	err := row.Scan(&comment.Id, &comment.TaskId, &comment.AuthorId, &comment.AuthorEmail, &comment.Body, &comment.DateCreated, &comment.DateEdited)
+	if err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetComments will get the comments of a task in chronological order starting after a cursor.
+// One comment more than the limit is returned so the caller knows if there is a next page.
+func (r *PostgresRepository) GetComments(taskId *uuid.UUID, after *Cursor, limit int) ([]Comment, error) {
+	condition := "c.task_id = $1"
+	args := []any{*taskId}
+	if after != nil {
+		condition += " AND (c.date_created, c.id) > ($2, $3)"
+		args = append(args, after.DateCreated, after.Id)
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf("SELECT %s FROM comments c JOIN users u ON u.id = c.author_id WHERE %s ORDER BY c.date_created, c.id LIMIT $%d",
+		commentColumns, condition, len(args))
+	log.Printf("Executing query in comment-PostgresRepository-GetComments: %s | Parameters %v", query, args)
+
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		log.Printf("Error in comment-PostgresRepository-GetComments: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]Comment, 0, limit+1)
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			log.Printf("Error in comment-PostgresRepository-GetComments: %v", err)
+			return nil, err
+		}
+		comments = append(comments, *comment)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in comment-PostgresRepository-GetComments: %v", err)
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetComment will get a comment by its id.
+func (r *PostgresRepository) GetComment(commentId *uuid.UUID) (*Comment, error) {
+	query := "SELECT " + commentColumns + " FROM comments c JOIN users u ON u.id = c.author_id WHERE c.id = $1"
+	log.Printf("Executing query in comment-PostgresRepository-GetComment: %s | Parameters %s", query, commentId)
+
+	comment, err := scanComment(r.database.QueryRow(query, *commentId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	} else if err != nil {
+		log.Printf("Error in comment-PostgresRepository-GetComment: %v", err)
+		return nil, err
+	}
+	return comment, nil
+}
+
+// AddComment will add a new comment.
+func (r *PostgresRepository) AddComment(comment *Comment) error {
+	query := "INSERT INTO comments(id, task_id, author_id, body, date_created, date_edited) VALUES ($1, $2, $3, $4, $5, $6)"
+	log.Printf("Executing query in comment-PostgresRepository-AddComment: %s | Parameters %s, %s, %s, %s, %v", query, comment.Id, comment.TaskId, comment.AuthorId, comment.DateCreated, comment.DateEdited)
+
+	_, err := r.database.Exec(query, comment.Id, comment.TaskId, comment.AuthorId, comment.Body, comment.DateCreated, comment.DateEdited)
+	if err != nil {
+		log.Printf("Error in comment-PostgresRepository-AddComment: %v", err)
+	}
+	return err
+}
+
+// UpdateComment will change the body of a comment and set its edit date.
+func (r *PostgresRepository) UpdateComment(commentId *uuid.UUID, body string, dateEdited time.Time) (*Comment, error) {
+	query := "UPDATE comments c SET body = $1, date_edited = $2 FROM users u WHERE c.id = $3 AND u.id = c.author_id RETURNING " + commentColumns
+	log.Printf("Executing query in comment-PostgresRepository-UpdateComment: %s | Parameters %s, %s", query, dateEdited, commentId)
+
+	comment, err := scanComment(r.database.QueryRow(query, body, dateEdited, *commentId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCommentNotFound
+	} else if err != nil {
+		log.Printf("Error in comment-PostgresRepository-UpdateComment: %v", err)
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment will delete a comment.
+func (r *PostgresRepository) DeleteComment(commentId *uuid.UUID) error {
+	query := "DELETE FROM comments WHERE id = $1"
+	log.Printf("Executing query in comment-PostgresRepository-DeleteComment: %s | Parameters %s", query, commentId)
+
+	result, err := r.database.Exec(query, *commentId)
+	if err != nil {
+		log.Printf("Error in comment-PostgresRepository-DeleteComment: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in comment-PostgresRepository-DeleteComment: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// NewPostgresRepository will create a new repository with a connection.
+func NewPostgresRepository(database *sql.DB) *PostgresRepository {
+	return &PostgresRepository{database}
+}
@@ -0,0 +1,25 @@
+package comment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the methods for a comment repository.
+type Repository interface {
+	// GetComments will get the comments of a task in chronological order starting after a cursor.
+	GetComments(*uuid.UUID, *Cursor, int) ([]Comment, error)
+
+	// GetComment will get a comment by its id.
+	GetComment(*uuid.UUID) (*Comment, error)
+
+	// AddComment will add a new comment.
+	AddComment(*Comment) error
+
+	// UpdateComment will change the body of a comment and set its edit date.
+	UpdateComment(*uuid.UUID, string, time.Time) (*Comment, error)
+
+	// DeleteComment will delete a comment.
+	DeleteComment(*uuid.UUID) error
+}
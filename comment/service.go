@@ -0,0 +1,205 @@
+package comment
+
+import (
+	"errors"
+	"log"
+	"task-server/middleware"
+	"task-server/task"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// TaskAccess is used to find the role of a user on a task.
+type TaskAccess interface {
+	// GetTaskAccess will get the owner of a task and the highest role a user has on it.
+	GetTaskAccess(*uuid.UUID, *uuid.UUID) (*uuid.UUID, task.Role, error)
+}
+
+// ServiceImp is an implementation of Service.
+type ServiceImp struct {
+	Repository    Repository
+	Tasks         TaskAccess
+	Authenticator middleware.Authenticator
+	// EditWindow is how long after its creation a comment can be edited.
+	EditWindow time.Duration
+}
+
+// checkBody will check that the body of a comment is not empty or too long.
+func checkBody(body string) bool {
+	return body != "" && utf8.RuneCountInString(body) <= MaxBodyLength
+}
+
+// taskRole will return the role of a user on a task, every role can read and write comments.
+func (s *ServiceImp) taskRole(userId *uuid.UUID, taskId *uuid.UUID) (task.Role, error) {
+	_, role, err := s.Tasks.GetTaskAccess(taskId, userId)
+	if errors.Is(err, task.ErrTaskNotFound) {
+		return "", ErrTaskNotFound
+	}
+	return role, err
+}
+
+// GetComments will return a page of the comments of a task in chronological order.
+func (s *ServiceImp) GetComments(tokenString *string, taskId *uuid.UUID, after *Cursor, limit int) (*Page, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-GetComments: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if limit < 1 || limit > MaxLimit {
+		return nil, ErrInvalidCursor
+	}
+
+	_, err = s.taskRole(id, taskId)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-GetComments: %v", err)
+		return nil, err
+	}
+
+	comments, err := s.Repository.GetComments(taskId, after, limit)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-GetComments: %v", err)
+		return nil, err
+	}
+
+	page := &Page{Comments: comments}
+	if len(comments) > limit {
+		page.Comments = comments[:limit]
+		last := page.Comments[limit-1]
+		page.Next, err = (&Cursor{DateCreated: last.DateCreated, Id: last.Id}).Encode()
+		if err != nil {
+			log.Printf("Error in comment-ServiceImp-GetComments: %v", err)
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// AddComment will add a comment written by the user to a task, every user the task is shared with can comment.
+func (s *ServiceImp) AddComment(tokenString *string, newComment *NewComment) (*Comment, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-AddComment: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkBody(newComment.Body) {
+		return nil, ErrInvalidComment
+	}
+
+	_, err = s.taskRole(id, &newComment.TaskId)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-AddComment: %v", err)
+		return nil, err
+	}
+
+	comment := &Comment{
+		Id:          uuid.New(),
+		TaskId:      newComment.TaskId,
+		AuthorId:    *id,
+		Body:        newComment.Body,
+		DateCreated: time.Now().UTC(),
+	}
+	err = s.Repository.AddComment(comment)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-AddComment: %v", err)
+		return nil, err
+	}
+
+	// The email of the author is read back so the response matches the listing.
+	addedComment, err := s.Repository.GetComment(&comment.Id)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-AddComment: %v", err)
+		return nil, err
+	}
+	return addedComment, nil
+}
+
+// UpdateComment will edit a comment, only its author can do it and only while the edit window is open.
+func (s *ServiceImp) UpdateComment(tokenString *string, update *CommentUpdate) (*Comment, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-UpdateComment: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkBody(update.Body) {
+		return nil, ErrInvalidComment
+	}
+
+	comment, err := s.Repository.GetComment(&update.Id)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-UpdateComment: %v", err)
+		return nil, err
+	}
+
+	_, err = s.taskRole(id, &comment.TaskId)
+	if errors.Is(err, ErrTaskNotFound) {
+		return nil, ErrCommentNotFound
+	} else if err != nil {
+		log.Printf("Error in comment-ServiceImp-UpdateComment: %v", err)
+		return nil, err
+	}
+
+	if comment.AuthorId != *id {
+		return nil, ErrForbidden
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(comment.DateCreated) > s.EditWindow {
+		return nil, ErrEditWindowExpired
+	}
+
+	updatedComment, err := s.Repository.UpdateComment(&update.Id, update.Body, now)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-UpdateComment: %v", err)
+		return nil, err
+	}
+	return updatedComment, nil
+}
+
+// DeleteComment will delete a comment, it can be done by its author and by the owner and the admins of the task.
+func (s *ServiceImp) DeleteComment(tokenString *string, commentId *uuid.UUID) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-DeleteComment: %v", err)
+		return ErrInvalidToken
+	}
+
+	comment, err := s.Repository.GetComment(commentId)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-DeleteComment: %v", err)
+		return err
+	}
+
+	role, err := s.taskRole(id, &comment.TaskId)
+	if errors.Is(err, ErrTaskNotFound) {
+		return ErrCommentNotFound
+	} else if err != nil {
+		log.Printf("Error in comment-ServiceImp-DeleteComment: %v", err)
+		return err
+	}
+
+	if comment.AuthorId != *id && role != task.RoleOwner && role != task.RoleAdmin {
+		return ErrForbidden
+	}
+
+	err = s.Repository.DeleteComment(commentId)
+	if err != nil {
+		log.Printf("Error in comment-ServiceImp-DeleteComment: %v", err)
+		return err
+	}
+	return nil
+}
+
+// NewServiceImp will create a new service with a repository, task access, authenticator and edit window.
+func NewServiceImp(repository Repository, tasks TaskAccess, authenticator middleware.Authenticator, editWindow time.Duration) *ServiceImp {
+	return &ServiceImp{
+		Repository:    repository,
+		Tasks:         tasks,
+		Authenticator: authenticator,
+		EditWindow:    editWindow,
+	}
+}
@@ -0,0 +1,18 @@
+package comment
+
+import "github.com/google/uuid"
+
+// Service defines the methods for a comment service.
+type Service interface {
+	// GetComments will return a page of the comments of a task in chronological order.
+	GetComments(*string, *uuid.UUID, *Cursor, int) (*Page, error)
+
+	// AddComment will add a comment written by the user to a task.
+	AddComment(*string, *NewComment) (*Comment, error)
+
+	// UpdateComment will edit a comment of the user while the edit window is open.
+	UpdateComment(*string, *CommentUpdate) (*Comment, error)
+
+	// DeleteComment will delete a comment.
+	DeleteComment(*string, *uuid.UUID) error
+}
@@ -0,0 +1,55 @@
+package comment
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want bool
+	}{
+		{"text", "Looks good", true},
+		{"empty", "", false},
+		{"longest", strings.Repeat("a", MaxBodyLength), true},
+		{"too long", strings.Repeat("a", MaxBodyLength+1), false},
+		{"longest in characters", strings.Repeat("ü", MaxBodyLength), true},
+	}
+
+	for _, test := range tests {
+		if got := checkBody(test.body); got != test.want {
+			t.Errorf("checkBody(%s) = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{DateCreated: time.Date(2026, time.March, 1, 9, 30, 0, 123, time.UTC), Id: uuid.New()}
+
+	encoded, err := cursor.Encode()
+	if err != nil {
+		t.Fatalf("Encode returned %v", err)
+	}
+	decoded, err := DecodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("DecodeCursor(%q) returned %v", encoded, err)
+	}
+	if !decoded.DateCreated.Equal(cursor.DateCreated) || decoded.Id != cursor.Id {
+		t.Errorf("DecodeCursor(Encode(%+v)) = %+v", cursor, *decoded)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, value := range []string{"!!", "bm90IGpzb24", "eyJpZCI6Im5vcGUifQ"} {
+		_, err := DecodeCursor(value)
+		if !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) returned %v, want %v", value, err, ErrInvalidCursor)
+		}
+	}
+}
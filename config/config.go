@@ -2,14 +2,21 @@ package config
 
 import (
 	"bufio"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"task-server/attachment"
+	"task-server/comment"
 	"task-server/middleware"
+	"task-server/reminder"
 	"task-server/task"
+	"task-server/timeentry"
 	"task-server/user"
+	"time"
 )
 
 // LoadEnvironmentFiles will load all the environment files from a .env file.
@@ -49,8 +56,75 @@ func LoadEnvironmentFiles(path string) {
 	}
 }
 
-// CreateHandlers will create the handlers for the server.
-func CreateHandlers() (user.Handler, task.Handler) {
+// getString will read an environment variable or return the fallback if it is not set.
+func getString(key string, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// getDuration will read a duration from an environment variable or return the fallback if it is not set or negative.
+func getDuration(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Error parsing %s, using %s: %s", key, fallback, err)
+		return fallback
+	}
+	if duration < 0 {
+		log.Printf("Error parsing %s, using %s: negative duration %s", key, fallback, duration)
+		return fallback
+	}
+	return duration
+}
+
+// getInterval will read the interval of a background job from an environment variable or return the fallback
+// if it is not set or not positive, as a ticker can not run with a zero interval.
+func getInterval(key string, fallback time.Duration) time.Duration {
+	interval := getDuration(key, fallback)
+	if interval <= 0 {
+		log.Printf("Error parsing %s, using %s: interval must be positive", key, fallback)
+		return fallback
+	}
+	return interval
+}
+
+// getInt will read an integer from an environment variable or return the fallback if it is not set.
+func getInt(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Error parsing %s, using %d: %s", key, fallback, err)
+		return fallback
+	}
+	return number
+}
+
+// createNotifier will create the notifier selected by REMINDER_NOTIFIER, reminders are only logged by default.
+func createNotifier() reminder.Notifier {
+	switch getString("REMINDER_NOTIFIER", "log") {
+	case "smtp":
+		return reminder.NewSMTPNotifier(getString("SMTP_HOST", "localhost"), getInt("SMTP_PORT", 25),
+			os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), getString("SMTP_FROM", "reminders@localhost"))
+	case "log":
+	default:
+		log.Printf("Unknown REMINDER_NOTIFIER, using log")
+	}
+	return &reminder.LogNotifier{}
+}
+
+// CreateHandlers will create the handlers for the server and start its background jobs.
+func CreateHandlers() (user.Handler, task.Handler, comment.Handler, attachment.Handler, reminder.Handler, timeentry.Handler) {
 	dbName := os.Getenv("DB_NAME")
 	dbUser := os.Getenv("DB_USERNAME")
 	dbPassword := os.Getenv("DB_PASSWORD")
@@ -75,8 +149,50 @@ func CreateHandlers() (user.Handler, task.Handler) {
 	userHandler := user.NewHandlerImp(userService)
 
 	taskRepository := task.NewRepository(db)
-	taskService := task.NewServiceImp(&taskRepository, authenticator)
+	undoWindow := getDuration("UNDO_WINDOW", time.Minute)
+	capacity := task.Capacity{
+		Minutes: int64(getInt("WORKLOAD_CAPACITY_MINUTES", 480)),
+		Points:  int64(getInt("WORKLOAD_CAPACITY_POINTS", 0)),
+	}
+	taskService := task.NewServiceImp(&taskRepository, authenticator, getInt("TASK_MAX_DEPTH", 5), undoWindow, capacity)
 	taskHandler := task.NewHandlerImp(&taskService)
 
-	return userHandler, &taskHandler
+	commentRepository := comment.NewPostgresRepository(db)
+	commentEditWindow := getDuration("COMMENT_EDIT_WINDOW", 15*time.Minute)
+	commentService := comment.NewServiceImp(commentRepository, &taskRepository, authenticator, commentEditWindow)
+	commentHandler := comment.NewHandlerImp(commentService)
+
+	attachmentStorage, err := attachment.NewLocalStorage(getString("ATTACHMENT_DIRECTORY", "attachments"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	attachmentRepository := attachment.NewPostgresRepository(db)
+	maxFileSize := int64(getInt("ATTACHMENT_MAX_FILE_SIZE", 25<<20))
+	userQuota := int64(getInt("ATTACHMENT_USER_QUOTA", 1<<30))
+	attachmentService := attachment.NewServiceImp(attachmentRepository, attachmentStorage, &taskRepository, authenticator, maxFileSize, userQuota)
+	attachmentHandler := attachment.NewHandlerImp(attachmentService)
+
+	reminderRepository := reminder.NewPostgresRepository(db)
+	reminderService := reminder.NewServiceImp(reminderRepository, &taskRepository, authenticator)
+	reminderHandler := reminder.NewHandlerImp(reminderService)
+
+	timeEntryRepository := timeentry.NewPostgresRepository(db)
+	timeEntryService := timeentry.NewServiceImp(timeEntryRepository, &taskRepository, authenticator)
+	timeEntryHandler := timeentry.NewHandlerImp(timeEntryService)
+
+	trashRetention := getDuration("TRASH_RETENTION", 30*24*time.Hour)
+	trashPurgeInterval := getInterval("TRASH_PURGE_INTERVAL", time.Hour)
+	taskPurger := task.NewPurger(&taskRepository, trashRetention, trashPurgeInterval)
+	go taskPurger.Run(context.Background())
+
+	taskRebalancer := task.NewRebalancer(&taskRepository, getInt("RANK_MAX_LENGTH", 24), getInterval("RANK_REBALANCE_INTERVAL", time.Hour))
+	go taskRebalancer.Run(context.Background())
+
+	attachmentSweeper := attachment.NewSweeper(attachmentRepository, attachmentStorage, getInterval("ATTACHMENT_SWEEP_INTERVAL", time.Hour))
+	go attachmentSweeper.Run(context.Background())
+
+	reminderScheduler := reminder.NewScheduler(reminderRepository, createNotifier(), getInterval("REMINDER_INTERVAL", time.Minute))
+	go reminderScheduler.Run(context.Background())
+
+	return userHandler, &taskHandler, commentHandler, attachmentHandler, reminderHandler, timeEntryHandler
 }
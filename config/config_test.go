@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetInterval(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", time.Minute},
+		{"30s", 30 * time.Second},
+		{"2h", 2 * time.Hour},
+		{"0s", time.Minute},
+		{"-5m", time.Minute},
+		{"often", time.Minute},
+	}
+
+	for _, test := range tests {
+		t.Setenv("TEST_INTERVAL", test.value)
+		got := getInterval("TEST_INTERVAL", time.Minute)
+		if got != test.want {
+			t.Errorf("getInterval(%q) = %s, want %s", test.value, got, test.want)
+		}
+	}
+}
+
+func TestGetDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", time.Hour},
+		{"0s", 0},
+		{"90m", 90 * time.Minute},
+		{"-1s", time.Hour},
+		{"1 day", time.Hour},
+	}
+
+	for _, test := range tests {
+		t.Setenv("TEST_DURATION", test.value)
+		got := getDuration("TEST_DURATION", time.Hour)
+		if got != test.want {
+			t.Errorf("getDuration(%q) = %s, want %s", test.value, got, test.want)
+		}
+	}
+}
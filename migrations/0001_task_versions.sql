@@ -0,0 +1,4 @@
+-- Migrations are applied in file name order on top of the users, tokens, task_priorities and tasks tables.
+
+-- version is increased by every change of a task and compared with the If-Match header.
+ALTER TABLE tasks ADD COLUMN version bigint NOT NULL DEFAULT 1;
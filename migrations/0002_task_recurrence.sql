@@ -0,0 +1,8 @@
+-- The occurrences of a recurring task share a series, the rule and index are those of the occurrence.
+ALTER TABLE tasks
+    ADD COLUMN recurrence_rule text,
+    ADD COLUMN series_id uuid,
+    ADD COLUMN recurrence_date timestamptz,
+    ADD COLUMN recurrence_index bigint;
+
+CREATE INDEX tasks_series_id_idx ON tasks (series_id) WHERE series_id IS NOT NULL;
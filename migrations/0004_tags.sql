@@ -0,0 +1,16 @@
+CREATE TABLE tags (
+    id uuid PRIMARY KEY,
+    name text NOT NULL,
+    color text NOT NULL,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX tags_user_id_idx ON tags (user_id);
+
+CREATE TABLE task_tags (
+    task_id uuid NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    tag_id uuid NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX task_tags_tag_id_idx ON task_tags (tag_id);
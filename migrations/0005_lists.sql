@@ -0,0 +1,27 @@
+CREATE TABLE lists (
+    id uuid PRIMARY KEY,
+    name text NOT NULL,
+    color text NOT NULL,
+    archived boolean NOT NULL DEFAULT false,
+    sort_order bigint NOT NULL DEFAULT 0,
+    inbox boolean NOT NULL DEFAULT false,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX lists_user_id_idx ON lists (user_id);
+
+-- Every user has a single inbox, concurrent requests adding it keep the first one.
+CREATE UNIQUE INDEX lists_inbox_idx ON lists (user_id) WHERE inbox;
+
+ALTER TABLE tasks ADD COLUMN list_id uuid REFERENCES lists (id);
+
+-- The existing tasks are moved to an inbox added for their owner.
+INSERT INTO lists (id, name, color, archived, sort_order, inbox, user_id)
+SELECT gen_random_uuid(), 'Inbox', '#808080', false, 0, true, id FROM users
+ON CONFLICT (user_id) WHERE inbox DO NOTHING;
+
+UPDATE tasks t SET list_id = l.id FROM lists l WHERE l.user_id = t.user_id AND l.inbox AND t.list_id IS NULL;
+
+ALTER TABLE tasks ALTER COLUMN list_id SET NOT NULL;
+
+CREATE INDEX tasks_list_id_idx ON tasks (list_id);
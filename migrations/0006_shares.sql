@@ -0,0 +1,13 @@
+-- A share gives a user a role on either a task or a list.
+CREATE TABLE shares (
+    id uuid PRIMARY KEY,
+    task_id uuid REFERENCES tasks (id) ON DELETE CASCADE,
+    list_id uuid REFERENCES lists (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role text NOT NULL CHECK (role IN ('viewer', 'editor', 'admin')),
+    CHECK ((task_id IS NULL) <> (list_id IS NULL))
+);
+
+CREATE UNIQUE INDEX shares_task_id_user_id_idx ON shares (task_id, user_id) WHERE task_id IS NOT NULL;
+CREATE UNIQUE INDEX shares_list_id_user_id_idx ON shares (list_id, user_id) WHERE list_id IS NOT NULL;
+CREATE INDEX shares_user_id_idx ON shares (user_id);
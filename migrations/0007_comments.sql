@@ -0,0 +1,11 @@
+CREATE TABLE comments (
+    id uuid PRIMARY KEY,
+    task_id uuid NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    author_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    body text NOT NULL,
+    date_created timestamptz NOT NULL,
+    date_edited timestamptz
+);
+
+-- Comments are paged by their creation date.
+CREATE INDEX comments_task_id_date_created_idx ON comments (task_id, date_created, id);
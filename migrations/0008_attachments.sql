@@ -0,0 +1,14 @@
+-- The task of an attachment is cleared when the task is removed, the blobs of such attachments are swept.
+CREATE TABLE attachments (
+    id uuid PRIMARY KEY,
+    task_id uuid REFERENCES tasks (id) ON DELETE SET NULL,
+    uploader_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    size bigint NOT NULL,
+    content_type text NOT NULL,
+    checksum text NOT NULL,
+    date_created timestamptz NOT NULL
+);
+
+CREATE INDEX attachments_task_id_idx ON attachments (task_id);
+CREATE INDEX attachments_uploader_id_idx ON attachments (uploader_id);
@@ -0,0 +1,15 @@
+-- A reminder fires at remind_at, or offset_minutes before the due date of its task.
+CREATE TABLE reminders (
+    id uuid PRIMARY KEY,
+    task_id uuid NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    remind_at timestamptz,
+    offset_minutes bigint,
+    status text NOT NULL CHECK (status IN ('pending', 'sending', 'sent', 'failed')),
+    date_sent timestamptz,
+    error text,
+    CHECK ((remind_at IS NULL) <> (offset_minutes IS NULL))
+);
+
+CREATE INDEX reminders_task_id_idx ON reminders (task_id);
+CREATE INDEX reminders_pending_idx ON reminders (task_id) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+-- The expression is the searchDocument of task/search.go, the two have to be changed together.
+CREATE INDEX tasks_search_idx ON tasks USING GIN ((setweight(to_tsvector('english', name), 'A') || setweight(to_tsvector('english', description), 'B')));
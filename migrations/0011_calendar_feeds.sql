@@ -0,0 +1,6 @@
+-- Every user has at most one feed token, only its hash is stored.
+CREATE TABLE calendar_feeds (
+    user_id uuid PRIMARY KEY REFERENCES users (id) ON DELETE CASCADE,
+    token_hash text NOT NULL UNIQUE,
+    date_created timestamptz NOT NULL
+);
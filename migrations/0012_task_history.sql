@@ -0,0 +1,12 @@
+-- History entries keep no reference to their task so they outlive tasks that are removed from the trash.
+CREATE TABLE task_history (
+    id uuid PRIMARY KEY,
+    task_id uuid NOT NULL,
+    actor_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    action text NOT NULL,
+    changes jsonb NOT NULL,
+    date_created timestamptz NOT NULL
+);
+
+CREATE INDEX task_history_task_id_idx ON task_history (task_id, date_created, id);
+CREATE INDEX task_history_actor_id_idx ON task_history (actor_id, date_created, id);
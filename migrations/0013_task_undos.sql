@@ -0,0 +1,12 @@
+-- Undos keep no reference to their task because they restore tasks that were removed.
+CREATE TABLE task_undos (
+    id uuid PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    owner_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    task_id uuid NOT NULL,
+    before jsonb NOT NULL,
+    after jsonb NOT NULL,
+    date_expires timestamptz NOT NULL
+);
+
+CREATE INDEX task_undos_date_expires_idx ON task_undos (date_expires);
@@ -0,0 +1,9 @@
+-- Rank keys are compared byte by byte, so they are always ordered with the C collation.
+ALTER TABLE tasks ADD COLUMN rank text NOT NULL DEFAULT '';
+
+-- The existing tasks of every user keep their due date order, the rebalance job respaces the keys later.
+UPDATE tasks t SET rank = lpad(r.n::text, 10, '0') || 'i'
+FROM (SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY due_date, id) AS n FROM tasks) r
+WHERE t.id = r.id;
+
+CREATE INDEX tasks_user_id_rank_idx ON tasks (user_id, rank COLLATE "C");
@@ -0,0 +1,9 @@
+-- A task is blocked by each of its blockers until they are completed or in the trash.
+CREATE TABLE task_dependencies (
+    task_id uuid NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    blocker_id uuid NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, blocker_id),
+    CHECK (task_id <> blocker_id)
+);
+
+CREATE INDEX task_dependencies_blocker_id_idx ON task_dependencies (blocker_id);
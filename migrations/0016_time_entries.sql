@@ -0,0 +1,14 @@
+-- An entry without an end is the running timer of its user.
+CREATE TABLE time_entries (
+    id uuid PRIMARY KEY,
+    task_id uuid NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    date_start timestamptz NOT NULL,
+    date_end timestamptz,
+    note text NOT NULL DEFAULT '',
+    CHECK (date_end IS NULL OR date_end >= date_start)
+);
+
+CREATE INDEX time_entries_task_id_idx ON time_entries (task_id, date_start);
+CREATE INDEX time_entries_user_id_idx ON time_entries (user_id, date_start);
+CREATE UNIQUE INDEX time_entries_running_idx ON time_entries (user_id) WHERE date_end IS NULL;
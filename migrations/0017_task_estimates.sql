@@ -0,0 +1,5 @@
+-- An estimate has both a value and a unit, or neither.
+ALTER TABLE tasks
+    ADD COLUMN estimate_value bigint,
+    ADD COLUMN estimate_unit text CHECK (estimate_unit IN ('minutes', 'points')),
+    ADD CHECK ((estimate_value IS NULL) = (estimate_unit IS NULL));
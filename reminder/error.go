@@ -0,0 +1,10 @@
+package reminder
+
+import "errors"
+
+var (
+	ErrInvalidToken     = errors.New("invalid token")
+	ErrInvalidReminder  = errors.New("invalid reminder")
+	ErrReminderNotFound = errors.New("reminder not found")
+	ErrTaskNotFound     = errors.New("task not found")
+)
@@ -0,0 +1,160 @@
+package reminder
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// HandlerImp implements Handler.
+type HandlerImp struct {
+	Service Service
+}
+
+// handleInvalidMethod will respond each time a request uses the wrong method.
+func (h *HandlerImp) handleInvalidMethod(w http.ResponseWriter) {
+	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+}
+
+// handleInvalidJson will respond to any invalid json formats send to the server.
+func (h *HandlerImp) handleInvalidJson(w http.ResponseWriter) {
+	http.Error(w, "Invalid json format", http.StatusBadRequest)
+}
+
+// handleServerError will respond each time there is a server error.
+func (h *HandlerImp) handleServerError(w http.ResponseWriter) {
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+// handleInvalidToken will respond each time there is an invalid token.
+func (h *HandlerImp) handleInvalidToken(w http.ResponseWriter) {
+	http.Error(w, "Invalid token", http.StatusUnauthorized)
+}
+
+// handleInvalidId will respond each time an id is not a valid uuid.
+func (h *HandlerImp) handleInvalidId(w http.ResponseWriter) {
+	http.Error(w, "Invalid id", http.StatusBadRequest)
+}
+
+// handleError will respond to the errors returned by the service.
+func (h *HandlerImp) handleError(w http.ResponseWriter, err error, source string) {
+	switch {
+	case errors.Is(err, ErrInvalidReminder):
+		http.Error(w, "Invalid reminder", http.StatusBadRequest)
+	case errors.Is(err, ErrReminderNotFound):
+		http.Error(w, "Reminder not found", http.StatusNotFound)
+	case errors.Is(err, ErrTaskNotFound):
+		http.Error(w, "Task not found", http.StatusNotFound)
+	case errors.Is(err, ErrInvalidToken):
+		h.handleInvalidToken(w)
+	default:
+		log.Printf("Error in reminder-HandlerImp-%s: %v", source, err)
+		h.handleServerError(w)
+	}
+}
+
+// HandleGet will handle get requests and send the reminders of the user on the task in the task query parameter.
+func (h *HandlerImp) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	taskId, err := uuid.Parse(r.URL.Query().Get("task"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	reminders, err := h.Service.GetReminders(&token, &taskId)
+	if err != nil {
+		h.handleError(w, err, "HandleGet")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(reminders)
+	if err != nil {
+		log.Printf("Error in reminder-HandlerImp-HandleGet: %v", err)
+	}
+}
+
+// HandlePost will handle post requests for adding a reminder.
+func (h *HandlerImp) HandlePost(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedReminder NewReminder
+	err = json.NewDecoder(r.Body).Decode(&receivedReminder)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	reminder, err := h.Service.AddReminder(&token, &receivedReminder)
+	if err != nil {
+		h.handleError(w, err, "HandlePost")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(reminder)
+	if err != nil {
+		log.Printf("Error in reminder-HandlerImp-HandlePost: %v", err)
+	}
+}
+
+// HandleDelete will handle delete requests for deleting a reminder.
+func (h *HandlerImp) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	err = h.Service.DeleteReminder(&token, &id)
+	if err != nil {
+		h.handleError(w, err, "HandleDelete")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// NewHandlerImp will create a new handler with a service.
+func NewHandlerImp(service Service) *HandlerImp {
+	return &HandlerImp{
+		Service: service,
+	}
+}
@@ -0,0 +1,15 @@
+package reminder
+
+import "net/http"
+
+// Handler defines methods for a reminder handler.
+type Handler interface {
+	// HandleGet will handle getting the reminders of a task.
+	HandleGet(w http.ResponseWriter, r *http.Request)
+
+	// HandlePost will handle adding a reminder.
+	HandlePost(w http.ResponseWriter, r *http.Request)
+
+	// HandleDelete will handle deleting a reminder.
+	HandleDelete(w http.ResponseWriter, r *http.Request)
+}
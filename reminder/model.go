@@ -0,0 +1,47 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status is the delivery state of a reminder.
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	// StatusSending is set when the scheduler claims a reminder, so it is never delivered twice.
+	StatusSending Status = "sending"
+	StatusSent    Status = "sent"
+	StatusFailed  Status = "failed"
+)
+
+// Reminder is a notification about a task sent to a user at an absolute time or some minutes before the due date.
+type Reminder struct {
+	Id            uuid.UUID  `json:"id"`
+	TaskId        uuid.UUID  `json:"taskId"`
+	RemindAt      *time.Time `json:"remindAt,omitempty"`
+	OffsetMinutes *int64     `json:"offsetMinutes,omitempty"`
+	// FireAt is when the reminder is sent, it follows the due date of the task for offset reminders.
+	FireAt   time.Time  `json:"fireAt"`
+	Status   Status     `json:"status"`
+	DateSent *time.Time `json:"dateSent"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// NewReminder is a reminder that will be added, exactly one of RemindAt and OffsetMinutes is set.
+type NewReminder struct {
+	TaskId        uuid.UUID  `json:"taskId"`
+	RemindAt      *time.Time `json:"remindAt"`
+	OffsetMinutes *int64     `json:"offsetMinutes"`
+}
+
+// Notification is the message a notifier delivers for a due reminder.
+type Notification struct {
+	ReminderId uuid.UUID
+	Email      string
+	TaskId     uuid.UUID
+	TaskName   string
+	DueDate    time.Time
+}
@@ -0,0 +1,76 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notifier delivers the notifications of due reminders.
+type Notifier interface {
+	// Notify will deliver a notification.
+	Notify(context.Context, *Notification) error
+}
+
+// LogNotifier is an implementation of Notifier writing the notifications to the log.
+type LogNotifier struct{}
+
+// Notify will log a notification.
+func (n *LogNotifier) Notify(ctx context.Context, notification *Notification) error {
+	log.Printf("Reminder %s for %s: task %q (%s) is due %s", notification.ReminderId, notification.Email,
+		notification.TaskName, notification.TaskId, notification.DueDate.Format(time.RFC3339))
+	return nil
+}
+
+// SMTPNotifier is an implementation of Notifier sending the notifications by email.
+type SMTPNotifier struct {
+	Host string
+	Port int
+	// Username and Password are used for PLAIN authentication, it is skipped when Username is empty.
+	Username string
+	Password string
+	From     string
+}
+
+// message will build the email of a notification.
+func (n *SMTPNotifier) message(notification *Notification) []byte {
+	name := strings.NewReplacer("\r", " ", "\n", " ").Replace(notification.TaskName)
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "From: %s\r\n", n.From)
+	fmt.Fprintf(&builder, "To: %s\r\n", notification.Email)
+	fmt.Fprintf(&builder, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "Reminder: "+name))
+	fmt.Fprintf(&builder, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	fmt.Fprintf(&builder, "Message-ID: <%s@%s>\r\n", notification.ReminderId, n.Host)
+	builder.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&builder, "Your task \"%s\" is due %s.\r\n", name, notification.DueDate.Format(time.RFC1123))
+	return []byte(builder.String())
+}
+
+// Notify will send a notification to the email of the user.
+func (n *SMTPNotifier) Notify(ctx context.Context, notification *Notification) error {
+	var auth smtp.Auth
+	if n.Username != "" {
+		auth = smtp.PlainAuth("", n.Username, n.Password, n.Host)
+	}
+
+	address := net.JoinHostPort(n.Host, strconv.Itoa(n.Port))
+	return smtp.SendMail(address, auth, n.From, []string{notification.Email}, n.message(notification))
+}
+
+// NewSMTPNotifier will create a new notifier with a server address, credentials and sender.
+func NewSMTPNotifier(host string, port int, username string, password string, from string) *SMTPNotifier {
+	return &SMTPNotifier{
+		Host:     host,
+		Port:     port,
+		Username: username,
+		Password: password,
+		From:     from,
+	}
+}
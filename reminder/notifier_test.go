@@ -0,0 +1,40 @@
+package reminder
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestSMTPNotifierMessage(t *testing.T) {
+	notifier := NewSMTPNotifier("mail.example.com", 25, "", "", "tasks@example.com")
+	notification := &Notification{
+		ReminderId: uuid.New(),
+		Email:      "user@example.com",
+		TaskName:   "Pay rent\r\nBcc: attacker@example.com",
+		DueDate:    time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	message := string(notifier.message(notification))
+	header, body, found := strings.Cut(message, "\r\n\r\n")
+	if !found {
+		t.Fatalf("message has no body: %q", message)
+	}
+
+	for _, line := range strings.Split(header, "\r\n") {
+		name, _, _ := strings.Cut(line, ":")
+		switch name {
+		case "From", "To", "Subject", "Date", "Message-ID", "Content-Type":
+		default:
+			t.Errorf("message has an unexpected header line %q", line)
+		}
+	}
+	if !strings.Contains(header, "To: user@example.com\r\n") || !strings.Contains(header, "Message-ID: <"+notification.ReminderId.String()+"@mail.example.com>") {
+		t.Errorf("message header = %q", header)
+	}
+	if !strings.Contains(body, `"Pay rent  Bcc: attacker@example.com"`) || !strings.Contains(body, "Sun, 01 Mar 2026 09:00:00 UTC") {
+		t.Errorf("message body = %q", body)
+	}
+}
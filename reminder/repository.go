@@ -0,0 +1,177 @@
+package reminder
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresRepository is an implementation of Repository.
+type PostgresRepository struct {
+	database *sql.DB
+}
+
+// fireAtColumn computes when a reminder fires, r is the reminders table and t the task.
+const fireAtColumn = "COALESCE(r.remind_at, t.due_date - r.offset_minutes * interval '1 minute')"
+
+// reminderColumns are the columns selected for every reminder.
+const reminderColumns = "r.id, r.task_id, r.remind_at, r.offset_minutes, " + fireAtColumn + ", r.status, r.date_sent, r.error"
+
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanReminder will scan a row selected with reminderColumns.
+func scanReminder(row rowScanner) (*Reminder, error) {
+	var reminder Reminder
+	var reminderError sql.NullString
+	err := row.Scan(&reminder.Id, &reminder.TaskId, &reminder.RemindAt, &reminder.OffsetMinutes, &reminder.FireAt,
+		&reminder.Status, &reminder.DateSent, &reminderError)
+	if err != nil {
+		return nil, err
+	}
+	reminder.Error = reminderError.String
+	return &reminder, nil
+}
+
+// GetReminders will get the reminders of a user on a task ordered by the time they fire.
+func (r *PostgresRepository) GetReminders(taskId *uuid.UUID, userId *uuid.UUID) ([]Reminder, error) {
+	query := "SELECT " + reminderColumns + " FROM reminders r JOIN tasks t ON t.id = r.task_id WHERE r.task_id = $1 AND r.user_id = $2 ORDER BY 5, r.id"
+	log.Printf("Executing query in reminder-PostgresRepository-GetReminders: %s | Parameters %s, %s", query, taskId, userId)
+
+	rows, err := r.database.Query(query, *taskId, *userId)
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-GetReminders: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	reminders := make([]Reminder, 0)
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			log.Printf("Error in reminder-PostgresRepository-GetReminders: %v", err)
+			return nil, err
+		}
+		reminders = append(reminders, *reminder)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-GetReminders: %v", err)
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// GetReminder will get a reminder of a user.
+func (r *PostgresRepository) GetReminder(reminderId *uuid.UUID, userId *uuid.UUID) (*Reminder, error) {
+	query := "SELECT " + reminderColumns + " FROM reminders r JOIN tasks t ON t.id = r.task_id WHERE r.id = $1 AND r.user_id = $2"
+	log.Printf("Executing query in reminder-PostgresRepository-GetReminder: %s | Parameters %s, %s", query, reminderId, userId)
+
+	reminder, err := scanReminder(r.database.QueryRow(query, *reminderId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrReminderNotFound
+	} else if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-GetReminder: %v", err)
+		return nil, err
+	}
+	return reminder, nil
+}
+
+// AddReminder will add a reminder of a user.
+func (r *PostgresRepository) AddReminder(reminder *Reminder, userId *uuid.UUID) error {
+	query := "INSERT INTO reminders(id, task_id, user_id, remind_at, offset_minutes, status) VALUES ($1, $2, $3, $4, $5, $6)"
+	log.Printf("Executing query in reminder-PostgresRepository-AddReminder: %s | Parameters %s, %s, %s, %v, %v, %s", query,
+		reminder.Id, reminder.TaskId, userId, reminder.RemindAt, reminder.OffsetMinutes, reminder.Status)
+
+	_, err := r.database.Exec(query, reminder.Id, reminder.TaskId, *userId, reminder.RemindAt, reminder.OffsetMinutes, reminder.Status)
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-AddReminder: %v", err)
+	}
+	return err
+}
+
+// DeleteReminder will delete a reminder of a user.
+func (r *PostgresRepository) DeleteReminder(reminderId *uuid.UUID, userId *uuid.UUID) error {
+	query := "DELETE FROM reminders WHERE id = $1 AND user_id = $2"
+	log.Printf("Executing query in reminder-PostgresRepository-DeleteReminder: %s | Parameters %s, %s", query, reminderId, userId)
+
+	result, err := r.database.Exec(query, *reminderId, *userId)
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-DeleteReminder: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-DeleteReminder: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+// ClaimDueReminders will mark at most limit pending reminders due before a time as sending and return their notifications.
+// Reminders of completed or deleted tasks are skipped. Locked rows are skipped too so concurrent schedulers never claim the same reminder.
+func (r *PostgresRepository) ClaimDueReminders(now time.Time, limit int) ([]Notification, error) {
+	query := "WITH due AS (SELECT r.id FROM reminders r JOIN tasks t ON t.id = r.task_id " +
+		"WHERE r.status = 'pending' AND " + fireAtColumn + " <= $1 AND t.date_completed IS NULL AND t.date_deleted IS NULL " +
+		"ORDER BY " + fireAtColumn + " LIMIT $2 FOR UPDATE OF r SKIP LOCKED) " +
+		"UPDATE reminders r SET status = 'sending' FROM due, tasks t, users u WHERE r.id = due.id AND t.id = r.task_id AND u.id = r.user_id " +
+		"RETURNING r.id, u.email, t.id, t.name, t.due_date"
+	log.Printf("Executing query in reminder-PostgresRepository-ClaimDueReminders: %s | Parameters %s, %d", query, now, limit)
+
+	rows, err := r.database.Query(query, now, limit)
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-ClaimDueReminders: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []Notification
+	for rows.Next() {
+		var notification Notification
+		err = rows.Scan(&notification.ReminderId, &notification.Email, &notification.TaskId, &notification.TaskName, &notification.DueDate)
+		if err != nil {
+			log.Printf("Error in reminder-PostgresRepository-ClaimDueReminders: %v", err)
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-ClaimDueReminders: %v", err)
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// FinishReminder will record the delivery of a claimed reminder, a non-empty error marks it as failed.
+func (r *PostgresRepository) FinishReminder(reminderId *uuid.UUID, dateSent time.Time, deliveryError string) error {
+	status := StatusSent
+	if deliveryError != "" {
+		status = StatusFailed
+	}
+
+	query := "UPDATE reminders SET status = $1, date_sent = $2, error = NULLIF($3, '') WHERE id = $4 AND status = 'sending'"
+	log.Printf("Executing query in reminder-PostgresRepository-FinishReminder: %s | Parameters %s, %s, %s, %s", query, status, dateSent, deliveryError, reminderId)
+
+	_, err := r.database.Exec(query, status, dateSent, deliveryError, *reminderId)
+	if err != nil {
+		log.Printf("Error in reminder-PostgresRepository-FinishReminder: %v", err)
+	}
+	return err
+}
+
+// NewPostgresRepository will create a new repository with a connection.
+func NewPostgresRepository(database *sql.DB) *PostgresRepository {
+	return &PostgresRepository{database}
+}
@@ -0,0 +1,28 @@
+package reminder
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the methods for a reminder repository.
+type Repository interface {
+	// GetReminders will get the reminders of a user on a task ordered by the time they fire.
+	GetReminders(*uuid.UUID, *uuid.UUID) ([]Reminder, error)
+
+	// GetReminder will get a reminder of a user.
+	GetReminder(*uuid.UUID, *uuid.UUID) (*Reminder, error)
+
+	// AddReminder will add a reminder of a user.
+	AddReminder(*Reminder, *uuid.UUID) error
+
+	// DeleteReminder will delete a reminder of a user.
+	DeleteReminder(*uuid.UUID, *uuid.UUID) error
+
+	// ClaimDueReminders will mark at most limit pending reminders due before a time as sending and return their notifications.
+	ClaimDueReminders(time.Time, int) ([]Notification, error)
+
+	// FinishReminder will record the delivery of a claimed reminder, a non-empty error marks it as failed.
+	FinishReminder(*uuid.UUID, time.Time, string) error
+}
@@ -0,0 +1,73 @@
+package reminder
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// batchSize is how many reminders the scheduler claims at once.
+const batchSize = 100
+
+// Scheduler will deliver the due reminders through a notifier.
+type Scheduler struct {
+	Repository Repository
+	Notifier   Notifier
+	// Interval is how often the due reminders are checked.
+	Interval time.Duration
+}
+
+// deliver will claim the due reminders and notify them until none is left.
+// A reminder is claimed before it is delivered and recorded after, so it is never delivered twice.
+func (s *Scheduler) deliver(ctx context.Context) {
+	for ctx.Err() == nil {
+		notifications, err := s.Repository.ClaimDueReminders(time.Now().UTC(), batchSize)
+		if err != nil {
+			log.Printf("Error in reminder-Scheduler-deliver: %v", err)
+			return
+		}
+
+		for _, notification := range notifications {
+			var deliveryError string
+			err = s.Notifier.Notify(ctx, &notification)
+			if err != nil {
+				log.Printf("Error in reminder-Scheduler-deliver: %v", err)
+				deliveryError = err.Error()
+			}
+
+			err = s.Repository.FinishReminder(&notification.ReminderId, time.Now().UTC(), deliveryError)
+			if err != nil {
+				log.Printf("Error in reminder-Scheduler-deliver: %v", err)
+			}
+		}
+
+		if len(notifications) < batchSize {
+			return
+		}
+	}
+}
+
+// Run will deliver the due reminders on every interval until the context is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.deliver(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewScheduler will create a new scheduler with a repository, notifier and check interval.
+func NewScheduler(repository Repository, notifier Notifier, interval time.Duration) Scheduler {
+	return Scheduler{
+		Repository: repository,
+		Notifier:   notifier,
+		Interval:   interval,
+	}
+}
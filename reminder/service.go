@@ -0,0 +1,134 @@
+package reminder
+
+import (
+	"errors"
+	"log"
+	"task-server/middleware"
+	"task-server/task"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxOffsetMinutes is the longest time before the due date a reminder can fire.
+const MaxOffsetMinutes = 366 * 24 * 60
+
+// TaskAccess is used to find the role of a user on a task.
+type TaskAccess interface {
+	// GetTaskAccess will get the owner of a task and the highest role a user has on it.
+	GetTaskAccess(*uuid.UUID, *uuid.UUID) (*uuid.UUID, task.Role, error)
+}
+
+// ServiceImp is an implementation of Service.
+type ServiceImp struct {
+	Repository    Repository
+	Tasks         TaskAccess
+	Authenticator middleware.Authenticator
+}
+
+// checkAccess will check that a user can see a task, every user the task is shared with can set reminders on it.
+func (s *ServiceImp) checkAccess(userId *uuid.UUID, taskId *uuid.UUID) error {
+	_, _, err := s.Tasks.GetTaskAccess(taskId, userId)
+	if errors.Is(err, task.ErrTaskNotFound) {
+		return ErrTaskNotFound
+	}
+	return err
+}
+
+// checkReminder will check that a reminder has exactly one of an absolute time in the future and a valid offset.
+func checkReminder(newReminder *NewReminder, now time.Time) bool {
+	if (newReminder.RemindAt == nil) == (newReminder.OffsetMinutes == nil) {
+		return false
+	}
+	if newReminder.RemindAt != nil {
+		return newReminder.RemindAt.After(now)
+	}
+	return *newReminder.OffsetMinutes >= 0 && *newReminder.OffsetMinutes <= MaxOffsetMinutes
+}
+
+// GetReminders will return the reminders of the user on a task.
+func (s *ServiceImp) GetReminders(tokenString *string, taskId *uuid.UUID) ([]Reminder, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-GetReminders: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	err = s.checkAccess(id, taskId)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-GetReminders: %v", err)
+		return nil, err
+	}
+
+	reminders, err := s.Repository.GetReminders(taskId, id)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-GetReminders: %v", err)
+		return nil, err
+	}
+	return reminders, nil
+}
+
+// AddReminder will add a reminder of the user on a task.
+func (s *ServiceImp) AddReminder(tokenString *string, newReminder *NewReminder) (*Reminder, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-AddReminder: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkReminder(newReminder, time.Now()) {
+		return nil, ErrInvalidReminder
+	}
+
+	err = s.checkAccess(id, &newReminder.TaskId)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-AddReminder: %v", err)
+		return nil, err
+	}
+
+	reminder := &Reminder{
+		Id:            uuid.New(),
+		TaskId:        newReminder.TaskId,
+		RemindAt:      newReminder.RemindAt,
+		OffsetMinutes: newReminder.OffsetMinutes,
+		Status:        StatusPending,
+	}
+	err = s.Repository.AddReminder(reminder, id)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-AddReminder: %v", err)
+		return nil, err
+	}
+
+	// The reminder is read back so the response has the time it fires.
+	addedReminder, err := s.Repository.GetReminder(&reminder.Id, id)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-AddReminder: %v", err)
+		return nil, err
+	}
+	return addedReminder, nil
+}
+
+// DeleteReminder will delete a reminder of the user.
+func (s *ServiceImp) DeleteReminder(tokenString *string, reminderId *uuid.UUID) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-DeleteReminder: %v", err)
+		return ErrInvalidToken
+	}
+
+	err = s.Repository.DeleteReminder(reminderId, id)
+	if err != nil {
+		log.Printf("Error in reminder-ServiceImp-DeleteReminder: %v", err)
+		return err
+	}
+	return nil
+}
+
+// NewServiceImp will create a new service with a repository, task access and authenticator.
+func NewServiceImp(repository Repository, tasks TaskAccess, authenticator middleware.Authenticator) *ServiceImp {
+	return &ServiceImp{
+		Repository:    repository,
+		Tasks:         tasks,
+		Authenticator: authenticator,
+	}
+}
@@ -0,0 +1,15 @@
+package reminder
+
+import "github.com/google/uuid"
+
+// Service defines the methods for a reminder service.
+type Service interface {
+	// GetReminders will return the reminders of the user on a task.
+	GetReminders(*string, *uuid.UUID) ([]Reminder, error)
+
+	// AddReminder will add a reminder of the user on a task.
+	AddReminder(*string, *NewReminder) (*Reminder, error)
+
+	// DeleteReminder will delete a reminder of the user.
+	DeleteReminder(*string, *uuid.UUID) error
+}
@@ -0,0 +1,35 @@
+package reminder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckReminder(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *time.Time { return &t }
+	offset := func(minutes int64) *int64 { return &minutes }
+
+	tests := []struct {
+		name     string
+		reminder NewReminder
+		want     bool
+	}{
+		{"future time", NewReminder{RemindAt: at(now.Add(time.Minute))}, true},
+		{"now", NewReminder{RemindAt: at(now)}, false},
+		{"past time", NewReminder{RemindAt: at(now.Add(-time.Minute))}, false},
+		{"offset", NewReminder{OffsetMinutes: offset(30)}, true},
+		{"offset at the due date", NewReminder{OffsetMinutes: offset(0)}, true},
+		{"largest offset", NewReminder{OffsetMinutes: offset(MaxOffsetMinutes)}, true},
+		{"offset too large", NewReminder{OffsetMinutes: offset(MaxOffsetMinutes + 1)}, false},
+		{"negative offset", NewReminder{OffsetMinutes: offset(-1)}, false},
+		{"neither", NewReminder{}, false},
+		{"both", NewReminder{RemindAt: at(now.Add(time.Hour)), OffsetMinutes: offset(30)}, false},
+	}
+
+	for _, test := range tests {
+		if got := checkReminder(&test.reminder, now); got != test.want {
+			t.Errorf("checkReminder(%s) = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package task
+
+import "github.com/google/uuid"
+
+// MaxBulkItems is the biggest number of operations a bulk request can have.
+const MaxBulkItems = 500
+
+// BulkAction is the operation done by an item of a bulk request.
+type BulkAction string
+
+const (
+	BulkCreate   BulkAction = "create"
+	BulkUpdate   BulkAction = "update"
+	BulkComplete BulkAction = "complete"
+	BulkDelete   BulkAction = "delete"
+)
+
+// BulkItem is a single operation of a bulk request.
+// Create uses Create, update uses Update and complete and delete use Id.
+type BulkItem struct {
+	Action BulkAction `json:"action"`
+	Id     uuid.UUID  `json:"id"`
+	Create *NewTask   `json:"create"`
+	Update *Task      `json:"update"`
+	// Version is the optional version an update or delete requires, like the If-Match header.
+	Version *int64 `json:"version"`
+	// Cascade completes or deletes the subtasks too.
+	Cascade bool `json:"cascade"`
+	// Force completes a task even if it has open blockers.
+	Force bool `json:"force"`
+}
+
+// BulkRequest is a list of operations run in one transaction.
+// In atomic mode a single failed item rolls back all of them.
+type BulkRequest struct {
+	Atomic bool       `json:"atomic"`
+	Items  []BulkItem `json:"items"`
+}
+
+// BulkResult is the outcome of an item of a bulk request, Err is nil if it succeeded.
+type BulkResult struct {
+	Task      *Task
+	UndoToken string
+	Err       error
+}
+
+// BulkResponse is the outcome of a bulk request with a result for each item in the same order.
+type BulkResponse struct {
+	Committed bool
+	Results   []BulkResult
+}
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+)
+
+// bulkItemBody is the json of the result of a bulk item, Status is the code the single request would respond with.
+type bulkItemBody struct {
+	Status    int    `json:"status"`
+	Task      *Task  `json:"task,omitempty"`
+	UndoToken string `json:"undoToken,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// bulkBody is the json of a bulk response.
+type bulkBody struct {
+	Committed bool           `json:"committed"`
+	Results   []bulkItemBody `json:"results"`
+}
+
+// bulkErrors maps the errors of a bulk item to the code and message of the single request handlers.
+var bulkErrors = []struct {
+	err     error
+	status  int
+	message string
+}{
+	{ErrInvalidBulk, http.StatusBadRequest, "Invalid bulk item"},
+	{ErrInvalidPriority, http.StatusBadRequest, "Invalid priority"},
+	{ErrInvalidEstimate, http.StatusBadRequest, "Invalid estimate"},
+	{ErrInvalidRecurrence, http.StatusBadRequest, "Invalid recurrence"},
+	{ErrInvalidParent, http.StatusBadRequest, "Invalid parent"},
+	{ErrMaxDepthExceeded, http.StatusBadRequest, "Max depth exceeded"},
+	{ErrInvalidList, http.StatusBadRequest, "Invalid list"},
+	{ErrForbidden, http.StatusForbidden, "Forbidden"},
+	{ErrTaskNotFound, http.StatusNotFound, "Task not found"},
+	{ErrVersionMismatch, http.StatusPreconditionFailed, "Precondition failed"},
+	{ErrTaskBlocked, http.StatusConflict, "Task is blocked"},
+}
+
+// newBulkItemBody will create the json of the result of a bulk item.
+// The successful items of a rolled back request are reported as failed dependencies.
+func newBulkItemBody(result *BulkResult, committed bool) bulkItemBody {
+	if result.Err == nil && committed {
+		return bulkItemBody{Status: http.StatusOK, Task: result.Task, UndoToken: result.UndoToken}
+	} else if result.Err == nil {
+		return bulkItemBody{Status: http.StatusFailedDependency, Error: "Rolled back"}
+	}
+
+	for _, known := range bulkErrors {
+		if errors.Is(result.Err, known.err) {
+			return bulkItemBody{Status: known.status, Error: known.message}
+		}
+	}
+	log.Printf("Error in task-HandlerImp-HandleBulk: %v", result.Err)
+	return bulkItemBody{Status: http.StatusInternalServerError, Error: "Internal server error"}
+}
+
+// HandleBulk will handle post requests running many task operations in one transaction.
+// It responds with a result for each item, in atomic mode a rolled back request responds with unprocessable entity.
+func (h *HandlerImp) HandleBulk(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var request BulkRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	response, err := h.Service.BulkTasks(&token, &request)
+	if errors.Is(err, ErrInvalidBulk) {
+		http.Error(w, "Invalid bulk request", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleBulk: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	body := bulkBody{
+		Committed: response.Committed,
+		Results:   make([]bulkItemBody, len(response.Results)),
+	}
+	for i := range response.Results {
+		body.Results[i] = newBulkItemBody(&response.Results[i], response.Committed)
+	}
+
+	status := http.StatusOK
+	if !response.Committed {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err = json.NewEncoder(w).Encode(body)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleBulk: %v", err)
+	}
+}
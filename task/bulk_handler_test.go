@@ -0,0 +1,72 @@
+package task
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHandleBulk(t *testing.T) {
+	tests := []struct {
+		name      string
+		atomic    bool
+		want      int
+		committed bool
+		statuses  []int
+	}{
+		{"atomic", true, http.StatusUnprocessableEntity, false, []int{http.StatusFailedDependency, http.StatusPreconditionFailed, http.StatusFailedDependency}},
+		{"not atomic", false, http.StatusOK, true, []int{http.StatusOK, http.StatusPreconditionFailed, http.StatusOK}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newMemoryRepository()
+			h := &HandlerImp{Service: newFakeService(repository)}
+			userId := uuid.New()
+			completed := repository.addTask(userId, "Report", nil)
+			updated := repository.addTask(userId, "Review", nil)
+			changed := updated
+			changed.Name = "Renamed"
+			stale := updated.Version + 1
+
+			request := BulkRequest{
+				Atomic: tt.atomic,
+				Items: []BulkItem{
+					{Action: BulkComplete, Id: completed.Id},
+					{Action: BulkUpdate, Update: &changed, Version: &stale},
+					{Action: BulkCreate, Create: &NewTask{Name: "Follow up", Priority: 1}},
+				},
+			}
+			w := serveTask(h.HandleBulk, http.MethodPost, "/tasks/bulk", userId, request)
+
+			var body bulkBody
+			err := json.NewDecoder(w.Body).Decode(&body)
+			if err != nil {
+				t.Fatalf("decoding the response returned %v", err)
+			}
+			statuses := make([]int, len(body.Results))
+			for i, result := range body.Results {
+				statuses[i] = result.Status
+			}
+			if w.Code != tt.want || body.Committed != tt.committed || !slices.Equal(statuses, tt.statuses) {
+				t.Errorf("response = %d, committed %t, statuses %v, want %d, %t, %v", w.Code, body.Committed, statuses, tt.want, tt.committed, tt.statuses)
+			}
+
+			if repository.tasks[completed.Id].DateCompleted.Valid != tt.committed {
+				t.Errorf("completed item was stored %t, want %t", repository.tasks[completed.Id].DateCompleted.Valid, tt.committed)
+			}
+			if repository.tasks[updated.Id].Name != updated.Name {
+				t.Error("the failed update was stored")
+			}
+			if created := len(repository.tasks) == 3; created != tt.committed {
+				t.Errorf("created item was stored %t, want %t", created, tt.committed)
+			}
+			if len(repository.history) > 0 != tt.committed || len(repository.undos) > 0 != tt.committed {
+				t.Errorf("stored %d history entries and %d undos", len(repository.history), len(repository.undos))
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package task
+
+import (
+	"errors"
+	"log"
+)
+
+// errBulkRolledBack makes the transaction of an atomic bulk request roll back after an item failed.
+var errBulkRolledBack = errors.New("bulk request rolled back")
+
+// runBulkItem will run a single item of a bulk request with the one-at-a-time methods of the service.
+// It returns the task and the undo token of the item.
+func (s *ServiceImp) runBulkItem(tokenString *string, item *BulkItem) (*Task, string, error) {
+	switch item.Action {
+	case BulkCreate:
+		if item.Create == nil {
+			return nil, "", ErrInvalidBulk
+		}
+		return s.AddTask(tokenString, item.Create)
+	case BulkUpdate:
+		if item.Update == nil {
+			return nil, "", ErrInvalidBulk
+		}
+		return s.UpdateTask(tokenString, item.Update, item.Version)
+	case BulkComplete:
+		return s.CompleteTask(tokenString, &item.Id, item.Cascade, item.Force)
+	case BulkDelete:
+		undoToken, err := s.DeleteTask(tokenString, &item.Id, item.Version, item.Cascade)
+		return nil, undoToken, err
+	default:
+		return nil, "", ErrInvalidBulk
+	}
+}
+
+// BulkTasks will run the items of a bulk request in one transaction.
+// Every item runs in its own savepoint, so a failed item never affects the others.
+// In atomic mode the whole transaction is rolled back if any item failed.
+func (s *ServiceImp) BulkTasks(tokenString *string, request *BulkRequest) (*BulkResponse, error) {
+	_, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-BulkTasks: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if len(request.Items) == 0 || len(request.Items) > MaxBulkItems {
+		return nil, ErrInvalidBulk
+	}
+
+	response := &BulkResponse{Results: make([]BulkResult, len(request.Items))}
+	err = s.Repository.Transaction(func(tx Repository) error {
+		service := *s
+		service.Repository = tx
+
+		failed := false
+		for i := range request.Items {
+			result := &response.Results[i]
+			result.Err = tx.Transaction(func(Repository) error {
+				var err error
+				result.Task, result.UndoToken, err = service.runBulkItem(tokenString, &request.Items[i])
+				return err
+			})
+			failed = failed || result.Err != nil
+		}
+
+		if request.Atomic && failed {
+			return errBulkRolledBack
+		}
+		return nil
+	})
+	if errors.Is(err, errBulkRolledBack) {
+		return response, nil
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-BulkTasks: %v", err)
+		return nil, err
+	}
+
+	response.Committed = true
+	return response, nil
+}
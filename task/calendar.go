@@ -0,0 +1,318 @@
+package task
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// calendarProductId is the PRODID of the calendars written by the server.
+const calendarProductId = "-//task-server//tasks//EN"
+
+// FeedPath is the path of the calendar feeds, the secret token of the user follows it.
+const FeedPath = "/calendar/feed/"
+
+// Priority is a row of the task priorities.
+type Priority struct {
+	Id   int64
+	Name string
+}
+
+// FeedToken is the secret a calendar app uses to subscribe to the tasks of a user.
+// Only its hash is stored, so the token is shown once when it is created.
+type FeedToken struct {
+	Token string `json:"token"`
+	Path  string `json:"path"`
+}
+
+// newFeedToken will create a random feed token and the hash stored for it.
+func newFeedToken() (*FeedToken, string, error) {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	return &FeedToken{Token: token, Path: FeedPath + token}, hashFeedToken(token), nil
+}
+
+// hashFeedToken will hash a feed token the way it is stored.
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// icalPriority will map a priority to the RFC 5545 scale by its name,
+// where 1 is the highest, 9 the lowest and 0 is undefined.
+func icalPriority(name string) int {
+	name = strings.ToLower(name)
+	switch {
+	case strings.Contains(name, "high"), strings.Contains(name, "urgent"):
+		return 1
+	case strings.Contains(name, "medium"), strings.Contains(name, "normal"):
+		return 5
+	case strings.Contains(name, "low"):
+		return 9
+	default:
+		return 0
+	}
+}
+
+// priorityForLevel will find the priority for a PRIORITY value.
+// RFC 5545 groups 1 to 4 as high, 5 as medium and 6 to 9 as low, undefined values use medium.
+// It falls back to the first priority if none has a matching name.
+func priorityForLevel(priorities []Priority, level int) (int64, bool) {
+	want := 5
+	if level >= 1 && level <= 4 {
+		want = 1
+	} else if level >= 6 {
+		want = 9
+	}
+
+	for _, priority := range priorities {
+		if icalPriority(priority.Name) == want {
+			return priority.Id, true
+		}
+	}
+	if len(priorities) > 0 {
+		return priorities[0].Id, true
+	}
+	return 0, false
+}
+
+// escapeText will escape a TEXT value.
+func escapeText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+// unescapeText will unescape a TEXT value.
+func unescapeText(value string) string {
+	var builder strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped && (r == 'n' || r == 'N'):
+			builder.WriteRune('\n')
+		case escaped:
+			builder.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			continue
+		default:
+			builder.WriteRune(r)
+		}
+		escaped = false
+	}
+	return builder.String()
+}
+
+// formatICalTime will format a time as a UTC DATE-TIME.
+func formatICalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// calendarWriter writes content lines folded at 75 octets and ended by CRLF.
+type calendarWriter struct {
+	writer *bufio.Writer
+}
+
+// line will write a content line.
+func (c *calendarWriter) line(name string, value string) {
+	line := name + ":" + value
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		c.writer.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the space starting a folded line counts towards its length
+		limit = 74
+	}
+	c.writer.WriteString(line + "\r\n")
+}
+
+// writeCalendar will write the tasks as a calendar of VTODO components.
+// The priority names are used to map the priorities of the tasks.
+func writeCalendar(w io.Writer, tasks []Task, priorityNames map[int64]string, now time.Time) error {
+	c := &calendarWriter{writer: bufio.NewWriter(w)}
+	c.line("BEGIN", "VCALENDAR")
+	c.line("VERSION", "2.0")
+	c.line("PRODID", calendarProductId)
+	c.line("CALSCALE", "GREGORIAN")
+	c.line("X-WR-CALNAME", "Tasks")
+
+	for _, task := range tasks {
+		c.line("BEGIN", "VTODO")
+		c.line("UID", task.Id.String())
+		c.line("DTSTAMP", formatICalTime(now))
+		c.line("SUMMARY", escapeText(task.Name))
+		if task.Description != "" {
+			c.line("DESCRIPTION", escapeText(task.Description))
+		}
+		c.line("DUE", formatICalTime(task.DueDate))
+		if priority := icalPriority(priorityNames[task.Priority]); priority != 0 {
+			c.line("PRIORITY", strconv.Itoa(priority))
+		}
+		if task.DateCompleted.Valid {
+			c.line("STATUS", "COMPLETED")
+			c.line("COMPLETED", formatICalTime(task.DateCompleted.Time))
+		} else {
+			c.line("STATUS", "NEEDS-ACTION")
+		}
+		c.line("END", "VTODO")
+	}
+
+	c.line("END", "VCALENDAR")
+	return c.writer.Flush()
+}
+
+// calendarProperty is a content line of a component.
+type calendarProperty struct {
+	Params map[string]string
+	Value  string
+}
+
+// calendarComponent is a VTODO or VEVENT of an imported calendar.
+type calendarComponent struct {
+	Kind       string
+	Properties map[string]calendarProperty
+}
+
+// parseContentLine will split a content line into its name, parameters and value.
+func parseContentLine(line string) (string, calendarProperty, bool) {
+	property := calendarProperty{Params: make(map[string]string)}
+
+	end := strings.IndexAny(line, ";:")
+	if end < 1 {
+		return "", property, false
+	}
+	name := strings.ToUpper(line[:end])
+	line = line[end:]
+
+	for line[0] == ';' {
+		equals := strings.IndexByte(line, '=')
+		if equals < 2 {
+			return "", property, false
+		}
+		param := strings.ToUpper(line[1:equals])
+		line = line[equals+1:]
+
+		var value string
+		if strings.HasPrefix(line, `"`) {
+			quote := strings.IndexByte(line[1:], '"')
+			if quote < 0 {
+				return "", property, false
+			}
+			value = line[1 : quote+1]
+			line = line[quote+2:]
+		} else {
+			end := strings.IndexAny(line, ";:")
+			if end < 0 {
+				return "", property, false
+			}
+			value = line[:end]
+			line = line[end:]
+		}
+		property.Params[param] = value
+
+		if line == "" {
+			return "", property, false
+		}
+	}
+
+	property.Value = line[1:]
+	return name, property, true
+}
+
+// parseCalendar will read the VTODO and VEVENT components of a calendar.
+// Nested components like VALARM are skipped.
+func parseCalendar(reader io.Reader) ([]calendarComponent, error) {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+		} else if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if scanner.Err() != nil {
+		return nil, importReadError(scanner.Err())
+	}
+	if len(lines) == 0 {
+		return nil, ErrInvalidImport
+	}
+
+	components := make([]calendarComponent, 0)
+	var current *calendarComponent
+	depth := 0
+	for i, line := range lines {
+		name, property, ok := parseContentLine(line)
+		if !ok || i == 0 && (name != "BEGIN" || !strings.EqualFold(property.Value, "VCALENDAR")) {
+			return nil, ErrInvalidImport
+		}
+
+		switch name {
+		case "BEGIN":
+			depth++
+			kind := strings.ToUpper(property.Value)
+			if depth == 2 && (kind == "VTODO" || kind == "VEVENT") {
+				current = &calendarComponent{Kind: kind, Properties: make(map[string]calendarProperty)}
+			}
+		case "END":
+			if depth == 2 && current != nil {
+				components = append(components, *current)
+				current = nil
+			}
+			depth--
+			if depth < 0 {
+				return nil, ErrInvalidImport
+			}
+		default:
+			if depth == 2 && current != nil {
+				if _, found := current.Properties[name]; !found {
+					current.Properties[name] = property
+				}
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, ErrInvalidImport
+	}
+	return components, nil
+}
+
+// parseICalTime will parse a DATE or DATE-TIME value.
+// Times with a TZID use that zone and floating times are read as UTC.
+func parseICalTime(property calendarProperty) (time.Time, error) {
+	if property.Params["VALUE"] == "DATE" || len(property.Value) == len("20060102") {
+		return time.Parse("20060102", property.Value)
+	}
+	if strings.HasSuffix(property.Value, "Z") {
+		return time.Parse("20060102T150405Z", property.Value)
+	}
+
+	location := time.UTC
+	if tzid := property.Params["TZID"]; tzid != "" {
+		var err error
+		location, err = time.LoadLocation(strings.TrimPrefix(tzid, "/"))
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.ParseInLocation("20060102T150405", property.Value, location)
+}
@@ -0,0 +1,147 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+)
+
+// handleFeedNotFound will respond each time the calendar feed token is unknown or was replaced.
+func (h *HandlerImp) handleFeedNotFound(w http.ResponseWriter) {
+	http.Error(w, "Calendar feed not found", http.StatusNotFound)
+}
+
+// HandlePostFeedToken will handle post requests creating the secret token of the calendar feed of a user.
+// The token is only shown in this response, posting again replaces it.
+func (h *HandlerImp) HandlePostFeedToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	feedToken, err := h.Service.CreateFeedToken(&token)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePostFeedToken: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(feedToken)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePostFeedToken: %v", err)
+	}
+}
+
+// HandleDeleteFeedToken will handle delete requests stopping the calendar feed of a user.
+func (h *HandlerImp) HandleDeleteFeedToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	err = h.Service.DeleteFeedToken(&token)
+	if errors.Is(err, ErrFeedNotFound) {
+		h.handleFeedNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleDeleteFeedToken: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleCalendarFeed will handle get requests for the calendar feed of the user owning the token in the path.
+// It does not need an access token and takes the same query parameters as listing tasks.
+func (h *HandlerImp) HandleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	filter, err := ParseFilter(r.URL.Query())
+	if errors.Is(err, ErrInvalidCursor) {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.handleInvalidFilter(w)
+		return
+	}
+
+	var buffer bytes.Buffer
+	err = h.Service.GetCalendarFeed(r.PathValue("token"), filter, &buffer)
+	if errors.Is(err, ErrFeedNotFound) {
+		h.handleFeedNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidFilter) {
+		h.handleInvalidFilter(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleCalendarFeed: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="tasks.ics"`)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.WriteHeader(http.StatusOK)
+	_, err = buffer.WriteTo(w)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleCalendarFeed: %v", err)
+	}
+}
+
+// HandleImportCalendar will handle post requests importing the VTODO and VEVENT components of an iCalendar body.
+// The dryRun query parameter only validates the components, bodies over MaxImportSize are rejected.
+func (h *HandlerImp) HandleImportCalendar(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		http.Error(w, "Invalid import", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.Service.ImportCalendar(&token, limitImport(w, r), dryRun)
+	if err != nil {
+		h.handleImportError(w, err, "HandleImportCalendar")
+		return
+	}
+
+	h.writeImportReport(w, report, "HandleImportCalendar")
+}
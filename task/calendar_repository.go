@@ -0,0 +1,91 @@
+package task
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// GetPriorities will get every task priority ordered by id.
+func (r *PostgresRepository) GetPriorities() ([]Priority, error) {
+	query := "SELECT id, name FROM task_priorities ORDER BY id"
+	log.Printf("Executing query in task-PostgresRepository-GetPriorities: %s", query)
+
+	rows, err := r.database.Query(query)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetPriorities: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	priorities := make([]Priority, 0)
+	for rows.Next() {
+		var priority Priority
+		err := rows.Scan(&priority.Id, &priority.Name)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetPriorities: %v", err)
+			return nil, err
+		}
+		priorities = append(priorities, priority)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetPriorities: %v", err)
+		return nil, err
+	}
+	return priorities, nil
+}
+
+// GetFeedUser will get the user of a calendar feed by the hash of its token.
+func (r *PostgresRepository) GetFeedUser(tokenHash string) (*uuid.UUID, error) {
+	query := "SELECT user_id FROM calendar_feeds WHERE token_hash = $1"
+	log.Printf("Executing query in task-PostgresRepository-GetFeedUser: %s", query)
+
+	var userId uuid.UUID
+	err := r.database.QueryRow(query, tokenHash).Scan(&userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrFeedNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetFeedUser: %v", err)
+		return nil, err
+	}
+	return &userId, nil
+}
+
+// SetFeedToken will set the hash of the calendar feed token of a user, replacing the previous one.
+func (r *PostgresRepository) SetFeedToken(userId *uuid.UUID, tokenHash string) error {
+	query := "INSERT INTO calendar_feeds(user_id, token_hash, date_created) VALUES ($1, $2, now()) " +
+		"ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, date_created = EXCLUDED.date_created"
+	log.Printf("Executing query in task-PostgresRepository-SetFeedToken: %s | Parameters %s", query, userId)
+
+	_, err := r.database.Exec(query, *userId, tokenHash)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-SetFeedToken: %v", err)
+	}
+	return err
+}
+
+// DeleteFeedToken will delete the calendar feed token of a user.
+func (r *PostgresRepository) DeleteFeedToken(userId *uuid.UUID) error {
+	query := "DELETE FROM calendar_feeds WHERE user_id = $1"
+	log.Printf("Executing query in task-PostgresRepository-DeleteFeedToken: %s | Parameters %s", query, userId)
+
+	result, err := r.database.Exec(query, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteFeedToken: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteFeedToken: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrFeedNotFound
+	}
+	return nil
+}
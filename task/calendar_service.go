@@ -0,0 +1,191 @@
+package task
+
+import (
+	"errors"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CreateFeedToken will create the secret token of the calendar feed of a user.
+// A previous token stops working.
+func (s *ServiceImp) CreateFeedToken(tokenString *string) (*FeedToken, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-CreateFeedToken: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	feedToken, hash, err := newFeedToken()
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-CreateFeedToken: %v", err)
+		return nil, err
+	}
+
+	err = s.Repository.SetFeedToken(id, hash)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-CreateFeedToken: %v", err)
+		return nil, err
+	}
+	return feedToken, nil
+}
+
+// DeleteFeedToken will stop the calendar feed of a user.
+func (s *ServiceImp) DeleteFeedToken(tokenString *string) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteFeedToken: %v", err)
+		return ErrInvalidToken
+	}
+
+	err = s.Repository.DeleteFeedToken(id)
+	if err != nil && !errors.Is(err, ErrFeedNotFound) {
+		log.Printf("Error in task-ServiceImp-DeleteFeedToken: %v", err)
+	}
+	return err
+}
+
+// GetCalendarFeed will write every task of the user of a feed token matching the filter as a calendar of VTODO components.
+// The feed token replaces the access token, so calendar apps can subscribe to it.
+func (s *ServiceImp) GetCalendarFeed(feedToken string, filter *Filter, w io.Writer) error {
+	id, err := s.Repository.GetFeedUser(hashFeedToken(feedToken))
+	if errors.Is(err, ErrFeedNotFound) {
+		return err
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-GetCalendarFeed: %v", err)
+		return err
+	}
+
+	if filter.Limit < 1 || filter.Limit > MaxLimit {
+		return ErrInvalidFilter
+	}
+
+	tasks, err := s.allTasks(id, filter)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetCalendarFeed: %v", err)
+		return err
+	}
+
+	priorities, err := s.Repository.GetPriorities()
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetCalendarFeed: %v", err)
+		return err
+	}
+
+	priorityNames := make(map[int64]string, len(priorities))
+	for _, priority := range priorities {
+		priorityNames[priority.Id] = priority.Name
+	}
+
+	err = writeCalendar(w, tasks, priorityNames, time.Now())
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetCalendarFeed: %v", err)
+	}
+	return err
+}
+
+// ImportCalendar will turn the VTODO and VEVENT components of a calendar into tasks of a user.
+// The errors are reported with the number of the component and the property,
+// and the tasks are added in one transaction only if no component has an error and it is not a dry run.
+func (s *ServiceImp) ImportCalendar(tokenString *string, reader io.Reader, dryRun bool) (*ImportReport, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportCalendar: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	components, err := parseCalendar(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(components) > MaxImportRows {
+		return nil, ErrInvalidImport
+	}
+
+	priorities, err := s.Repository.GetPriorities()
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportCalendar: %v", err)
+		return nil, err
+	}
+
+	report := &ImportReport{DryRun: dryRun, Rows: len(components), Errors: make([]ImportError, 0)}
+	rows := make([]importRow, len(components))
+	for i := range components {
+		report.Errors = append(report.Errors, validateComponent(i+1, &components[i], priorities, &rows[i])...)
+	}
+
+	if len(report.Errors) > 0 || dryRun {
+		return report, nil
+	}
+
+	err = s.addImportedRows(tokenString, id, rows)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportCalendar: %v", err)
+		return nil, err
+	}
+
+	report.Imported = len(rows)
+	return report, nil
+}
+
+// validateComponent will fill a row from a component and return its validation errors.
+// A VTODO is due at its DUE or else its DTSTART, a VEVENT at its DTSTART.
+func validateComponent(number int, component *calendarComponent, priorities []Priority, row *importRow) []ImportError {
+	componentErrors := make([]ImportError, 0)
+	invalid := func(property string, message string) {
+		componentErrors = append(componentErrors, ImportError{Row: number, Column: property, Message: message})
+	}
+
+	row.task.Name = strings.TrimSpace(unescapeText(component.Properties["SUMMARY"].Value))
+	if row.task.Name == "" {
+		invalid("SUMMARY", "summary is required")
+	}
+	row.task.Description = unescapeText(component.Properties["DESCRIPTION"].Value)
+
+	level := 0
+	if property, ok := component.Properties["PRIORITY"]; ok {
+		var err error
+		level, err = strconv.Atoi(strings.TrimSpace(property.Value))
+		if err != nil || level < 0 || level > 9 {
+			invalid("PRIORITY", "invalid priority")
+		}
+	}
+	priority, ok := priorityForLevel(priorities, level)
+	if !ok {
+		invalid("PRIORITY", "unknown priority")
+	}
+	row.task.Priority = priority
+
+	dueName := "DTSTART"
+	_, hasDue := component.Properties["DUE"]
+	_, hasStart := component.Properties["DTSTART"]
+	if component.Kind == "VTODO" && (hasDue || !hasStart) {
+		dueName = "DUE"
+	}
+	if property, ok := component.Properties[dueName]; !ok {
+		invalid(dueName, "date is required")
+	} else if dueDate, err := parseICalTime(property); err != nil {
+		invalid(dueName, "invalid date")
+	} else {
+		row.task.DueDate = dueDate
+	}
+
+	if component.Kind != "VTODO" {
+		return componentErrors
+	}
+
+	if property, ok := component.Properties["COMPLETED"]; ok {
+		dateCompleted, err := parseICalTime(property)
+		if err != nil {
+			invalid("COMPLETED", "invalid date")
+		}
+		row.dateCompleted = &dateCompleted
+	} else if strings.EqualFold(component.Properties["STATUS"].Value, "COMPLETED") {
+		now := time.Now()
+		row.dateCompleted = &now
+	}
+
+	return componentErrors
+}
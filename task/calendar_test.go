@@ -0,0 +1,286 @@
+package task
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestEscapeText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Call the bank", "Call the bank"},
+		{"a;b,c", `a\;b\,c`},
+		{`C:\temp`, `C:\\temp`},
+		{"line\nbreak\r\nend", `line\nbreak\nend`},
+	}
+
+	for _, test := range tests {
+		escaped := escapeText(test.value)
+		if escaped != test.want {
+			t.Errorf("escapeText(%q) = %q, want %q", test.value, escaped, test.want)
+		}
+	}
+}
+
+func TestUnescapeText(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{`a\;b\,c`, "a;b,c"},
+		{`one\ntwo\Nthree`, "one\ntwo\nthree"},
+		{`C:\\temp\\new`, `C:\temp\new`},
+		{`colon\:kept`, "colon:kept"},
+		{`trailing\`, "trailing"},
+	}
+
+	for _, test := range tests {
+		unescaped := unescapeText(test.value)
+		if unescaped != test.want {
+			t.Errorf("unescapeText(%q) = %q, want %q", test.value, unescaped, test.want)
+		}
+	}
+}
+
+func TestEscapeTextRoundTrip(t *testing.T) {
+	tests := []string{
+		"",
+		"plain",
+		`\n is not a line break`,
+		"semi;colon, comma and \\ backslash",
+		"multi\nline\ntext",
+		"ünïcödé ✓",
+	}
+
+	for _, value := range tests {
+		got := unescapeText(escapeText(value))
+		if got != value {
+			t.Errorf("unescapeText(escapeText(%q)) = %q", value, got)
+		}
+	}
+}
+
+func TestParseContentLine(t *testing.T) {
+	tests := []struct {
+		line   string
+		name   string
+		params map[string]string
+		value  string
+	}{
+		{"SUMMARY:Call the bank", "SUMMARY", map[string]string{}, "Call the bank"},
+		{"summary:lower case", "SUMMARY", map[string]string{}, "lower case"},
+		{"DUE;VALUE=DATE:20260201", "DUE", map[string]string{"VALUE": "DATE"}, "20260201"},
+		{`DTSTART;TZID="Europe/Berlin";X-A=b:20260201T090000`, "DTSTART", map[string]string{"TZID": "Europe/Berlin", "X-A": "b"}, "20260201T090000"},
+		{`DESCRIPTION;ALTREP="cid:a;b":text: with colon`, "DESCRIPTION", map[string]string{"ALTREP": "cid:a;b"}, "text: with colon"},
+		{"X-EMPTY:", "X-EMPTY", map[string]string{}, ""},
+	}
+
+	for _, test := range tests {
+		name, property, ok := parseContentLine(test.line)
+		if !ok || name != test.name || !reflect.DeepEqual(property.Params, test.params) || property.Value != test.value {
+			t.Errorf("parseContentLine(%q) = %q, %+v, %t", test.line, name, property, ok)
+		}
+	}
+
+	for _, line := range []string{":value", "NAME", "NAME;PARAM:value", `NAME;P="open:value`, "NAME;P=value"} {
+		_, _, ok := parseContentLine(line)
+		if ok {
+			t.Errorf("parseContentLine(%q) succeeded", line)
+		}
+	}
+}
+
+func TestParseCalendar(t *testing.T) {
+	text := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VTODO\r\n" +
+		"SUMMARY:Write the\r\n" +
+		"  report\r\n" +
+		"SUMMARY:ignored\r\n" +
+		"BEGIN:VALARM\r\n" +
+		"TRIGGER:-PT15M\r\n" +
+		"END:VALARM\r\n" +
+		"END:VTODO\r\n" +
+		"BEGIN:VTIMEZONE\r\n" +
+		"TZID:Europe/Berlin\r\n" +
+		"END:VTIMEZONE\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Meeting\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	components, err := parseCalendar(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("parseCalendar returned %v", err)
+	}
+	if len(components) != 2 {
+		t.Fatalf("parseCalendar returned %d components, want 2", len(components))
+	}
+
+	todo, event := components[0], components[1]
+	if todo.Kind != "VTODO" || todo.Properties["SUMMARY"].Value != "Write the report" {
+		t.Errorf("first component = %+v", todo)
+	}
+	if _, found := todo.Properties["TRIGGER"]; found {
+		t.Errorf("properties of a nested component were kept: %+v", todo.Properties)
+	}
+	if event.Kind != "VEVENT" || event.Properties["SUMMARY"].Value != "Meeting" {
+		t.Errorf("second component = %+v", event)
+	}
+}
+
+func TestParseCalendarInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"BEGIN:VTODO\nEND:VTODO\n",
+		"BEGIN:VCALENDAR\nBEGIN:VTODO\nEND:VTODO\n",
+		"BEGIN:VCALENDAR\nEND:VCALENDAR\nEND:VCALENDAR\n",
+		"BEGIN:VCALENDAR\nnot a content line\nEND:VCALENDAR\n",
+	}
+
+	for _, text := range tests {
+		_, err := parseCalendar(strings.NewReader(text))
+		if !errors.Is(err, ErrInvalidImport) {
+			t.Errorf("parseCalendar(%q) returned %v, want %v", text, err, ErrInvalidImport)
+		}
+	}
+}
+
+func TestCalendarRoundTrip(t *testing.T) {
+	due := time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC)
+	completed := time.Date(2026, time.January, 30, 17, 45, 0, 0, time.UTC)
+	tasks := []Task{
+		{
+			Id:          uuid.New(),
+			Name:        "Call the bank; ask about fees, rates and the \\ branch",
+			Description: "First line\nSecond line with a long text that will need folding: " + strings.Repeat("ünïcödé ", 20),
+			Priority:    1,
+			DueDate:     due,
+		},
+		{
+			Id:            uuid.New(),
+			Name:          "Done",
+			Priority:      2,
+			DueDate:       due,
+			DateCompleted: NullTime{sql.NullTime{Time: completed, Valid: true}},
+		},
+	}
+	priorityNames := map[int64]string{1: "High", 2: "Unknown"}
+
+	var buffer bytes.Buffer
+	err := writeCalendar(&buffer, tasks, priorityNames, time.Now())
+	if err != nil {
+		t.Fatalf("writeCalendar returned %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(buffer.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line %q is longer than 75 octets", line)
+		}
+	}
+
+	components, err := parseCalendar(&buffer)
+	if err != nil {
+		t.Fatalf("parseCalendar returned %v", err)
+	}
+	if len(components) != len(tasks) {
+		t.Fatalf("parseCalendar returned %d components, want %d", len(components), len(tasks))
+	}
+
+	for i, component := range components {
+		task := tasks[i]
+		properties := component.Properties
+		if properties["UID"].Value != task.Id.String() {
+			t.Errorf("UID = %q, want %s", properties["UID"].Value, task.Id)
+		}
+		if name := unescapeText(properties["SUMMARY"].Value); name != task.Name {
+			t.Errorf("SUMMARY = %q, want %q", name, task.Name)
+		}
+		if description := unescapeText(properties["DESCRIPTION"].Value); description != task.Description {
+			t.Errorf("DESCRIPTION = %q, want %q", description, task.Description)
+		}
+
+		dueDate, err := parseICalTime(properties["DUE"])
+		if err != nil || !dueDate.Equal(task.DueDate) {
+			t.Errorf("DUE = %v, %v, want %v", dueDate, err, task.DueDate)
+		}
+		if task.DateCompleted.Valid {
+			dateCompleted, err := parseICalTime(properties["COMPLETED"])
+			if err != nil || !dateCompleted.Equal(completed) || properties["STATUS"].Value != "COMPLETED" {
+				t.Errorf("COMPLETED = %v, %v, STATUS = %s", dateCompleted, err, properties["STATUS"].Value)
+			}
+		}
+	}
+
+	if components[0].Properties["PRIORITY"].Value != "1" {
+		t.Errorf("PRIORITY = %q, want 1", components[0].Properties["PRIORITY"].Value)
+	}
+	if _, found := components[1].Properties["PRIORITY"]; found {
+		t.Error("PRIORITY is written for a priority without a known name")
+	}
+}
+
+func TestParseICalTime(t *testing.T) {
+	tests := []struct {
+		property calendarProperty
+		want     time.Time
+	}{
+		{calendarProperty{Value: "20260201T090000Z"}, time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC)},
+		{calendarProperty{Value: "20260201T090000"}, time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC)},
+		{calendarProperty{Value: "20260201"}, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{calendarProperty{Params: map[string]string{"VALUE": "DATE"}, Value: "20260201"}, time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{calendarProperty{Params: map[string]string{"TZID": "UTC"}, Value: "20260201T090000"}, time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		got, err := parseICalTime(test.property)
+		if err != nil || !got.Equal(test.want) {
+			t.Errorf("parseICalTime(%+v) = %v, %v, want %v", test.property, got, err, test.want)
+		}
+	}
+
+	_, err := parseICalTime(calendarProperty{Params: map[string]string{"TZID": "Nowhere/Unknown"}, Value: "20260201T090000"})
+	if err == nil {
+		t.Error("parseICalTime with an unknown TZID returned no error")
+	}
+}
+
+func TestPriorityForLevel(t *testing.T) {
+	priorities := []Priority{{Id: 1, Name: "Low"}, {Id: 2, Name: "Medium"}, {Id: 3, Name: "High"}}
+
+	tests := []struct {
+		level int
+		want  int64
+	}{
+		{1, 3},
+		{4, 3},
+		{5, 2},
+		{0, 2},
+		{6, 1},
+		{9, 1},
+	}
+
+	for _, test := range tests {
+		got, ok := priorityForLevel(priorities, test.level)
+		if !ok || got != test.want {
+			t.Errorf("priorityForLevel(%d) = %d, %t, want %d", test.level, got, ok, test.want)
+		}
+	}
+
+	got, ok := priorityForLevel([]Priority{{Id: 7, Name: "Someday"}}, 1)
+	if !ok || got != 7 {
+		t.Errorf("priorityForLevel without matching names = %d, %t, want the first priority", got, ok)
+	}
+	_, ok = priorityForLevel(nil, 1)
+	if ok {
+		t.Error("priorityForLevel without priorities found one")
+	}
+}
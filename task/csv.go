@@ -0,0 +1,171 @@
+package task
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxImportRows is the biggest number of tasks a CSV import can have.
+const MaxImportRows = 5000
+
+// MaxImportSize is the biggest body in bytes an import can have.
+const MaxImportSize = 10 << 20
+
+// importReadError will return the error of reading an import.
+// The cause is kept so a body over the size limit can be told apart from a malformed one.
+func importReadError(err error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidImport, err)
+}
+
+// CSV columns of the tasks. Exported files have the header
+//
+//	id,name,description,priority,dueDate,dateCompleted,list
+//
+// Dates use RFC 3339 and the export writes the priority id and the list name.
+// An import reads the same header and ignores id. The priority can be an id or a name,
+// the list can be an id or the name of a list of the user and dates can also be written as 2006-01-02.
+// Name, priority and dueDate are required, rows without a list are added to the inbox.
+const (
+	ColumnId            = "id"
+	ColumnName          = "name"
+	ColumnDescription   = "description"
+	ColumnPriority      = "priority"
+	ColumnDueDate       = "dueDate"
+	ColumnDateCompleted = "dateCompleted"
+	ColumnList          = "list"
+)
+
+// exportColumns are the columns written by an export in order.
+var exportColumns = []string{ColumnId, ColumnName, ColumnDescription, ColumnPriority, ColumnDueDate, ColumnDateCompleted, ColumnList}
+
+// importColumns are the columns an import can read.
+var importColumns = []string{ColumnName, ColumnDescription, ColumnPriority, ColumnDueDate, ColumnDateCompleted, ColumnList}
+
+// ImportOptions change how a CSV file is imported.
+type ImportOptions struct {
+	// DryRun only validates the rows, nothing is saved.
+	DryRun bool
+	// Mapping maps the header of the file to the columns of the tasks.
+	// Headers that are not mapped are matched to the columns ignoring case.
+	Mapping map[string]string
+}
+
+// ImportError is a validation error of a row, rows are counted from 1 after the header.
+type ImportError struct {
+	Row     int    `json:"row"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportReport is the outcome of an import.
+// Tasks are only imported when no row has an error.
+type ImportReport struct {
+	DryRun   bool          `json:"dryRun"`
+	Rows     int           `json:"rows"`
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// importRow is a validated row of an import.
+type importRow struct {
+	task          NewTask
+	tags          []uuid.UUID
+	dateCompleted *time.Time
+}
+
+// ParseMapping will parse a mapping written as header:column pairs separated by commas.
+func ParseMapping(value string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	if value == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(value, ",") {
+		header, column, ok := strings.Cut(pair, ":")
+		if !ok || !slices.Contains(importColumns, column) {
+			return nil, ErrInvalidImport
+		}
+		mapping[strings.TrimSpace(header)] = column
+	}
+	return mapping, nil
+}
+
+// mapHeader will find the index of every mapped column in the header of a file.
+func mapHeader(header []string, mapping map[string]string) map[string]int {
+	indexes := make(map[string]int)
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if column, ok := mapping[name]; ok {
+			indexes[column] = i
+			continue
+		}
+
+		for _, column := range importColumns {
+			if strings.EqualFold(name, column) {
+				if _, mapped := indexes[column]; !mapped {
+					indexes[column] = i
+				}
+			}
+		}
+	}
+	return indexes
+}
+
+// parseCSVDate will parse a date in RFC 3339 or as a day.
+func parseCSVDate(value string) (time.Time, error) {
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		date, err = time.Parse(time.DateOnly, value)
+	}
+	return date, err
+}
+
+// writeCSV will write the header and a row for every task to an export.
+func writeCSV(writer *csv.Writer, tasks []Task, listNames map[uuid.UUID]string) error {
+	err := writer.Write(exportColumns)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		dateCompleted := ""
+		if task.DateCompleted.Valid {
+			dateCompleted = task.DateCompleted.Time.Format(time.RFC3339)
+		}
+
+		list, ok := listNames[task.ListId]
+		if !ok {
+			list = task.ListId.String()
+		}
+
+		err := writer.Write([]string{
+			task.Id.String(),
+			task.Name,
+			task.Description,
+			strconv.FormatInt(task.Priority, 10),
+			task.DueDate.Format(time.RFC3339),
+			dateCompleted,
+			list,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// newCSVReader will create a reader for an import, rows may have any number of fields.
+func newCSVReader(reader io.Reader) *csv.Reader {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	return csvReader
+}
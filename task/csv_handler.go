@@ -0,0 +1,147 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"task-server/middleware"
+)
+
+// HandleExport will handle get requests exporting the tasks of a user as CSV.
+// It takes the same query parameters as listing tasks.
+func (h *HandlerImp) HandleExport(w http.ResponseWriter, r *http.Request) {
+	h.handleExport(w, r, h.Service.ExportTasks, "text/csv; charset=utf-8", "tasks.csv", "HandleExport")
+}
+
+// handleExport will handle the get requests exporting the tasks of a user matching the listing query parameters as a file.
+func (h *HandlerImp) handleExport(w http.ResponseWriter, r *http.Request, export func(*string, *Filter, io.Writer) error, contentType string, filename string, source string) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	filter, err := ParseFilter(r.URL.Query())
+	if errors.Is(err, ErrInvalidCursor) {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.handleInvalidFilter(w)
+		return
+	}
+
+	var buffer bytes.Buffer
+	err = export(&token, filter, &buffer)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrInvalidFilter) {
+		h.handleInvalidFilter(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-%s: %v", source, err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	_, err = buffer.WriteTo(w)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-%s: %v", source, err)
+	}
+}
+
+// parseDryRun will read the dryRun query parameter of an import.
+func parseDryRun(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("dryRun")
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// HandleImport will handle post requests importing tasks from a CSV body.
+// The map query parameter maps the header of the file to columns and dryRun only validates the rows.
+// It responds with a report, with unprocessable entity if any row is invalid, and with request entity too large
+// if the body is over MaxImportSize.
+func (h *HandlerImp) HandleImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	options := &ImportOptions{}
+	options.DryRun, err = parseDryRun(r)
+	if err != nil {
+		http.Error(w, "Invalid import", http.StatusBadRequest)
+		return
+	}
+
+	options.Mapping, err = ParseMapping(r.URL.Query().Get("map"))
+	if err != nil {
+		http.Error(w, "Invalid import", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.Service.ImportTasks(&token, limitImport(w, r), options)
+	if err != nil {
+		h.handleImportError(w, err, "HandleImport")
+		return
+	}
+
+	h.writeImportReport(w, report, "HandleImport")
+}
+
+// limitImport will limit the body of an import request to MaxImportSize bytes.
+func limitImport(w http.ResponseWriter, r *http.Request) io.Reader {
+	return http.MaxBytesReader(w, r.Body, MaxImportSize)
+}
+
+// handleImportError will respond to the errors of an import, a body over the size limit is too large.
+func (h *HandlerImp) handleImportError(w http.ResponseWriter, err error, source string) {
+	var maxBytesError *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesError):
+		http.Error(w, "Import too large", http.StatusRequestEntityTooLarge)
+	case errors.Is(err, ErrInvalidImport):
+		http.Error(w, "Invalid import", http.StatusBadRequest)
+	case errors.Is(err, ErrInvalidToken):
+		h.handleInvalidToken(w)
+	default:
+		log.Printf("Error in task-HandlerImp-%s: %v", source, err)
+		h.handleServerError(w)
+	}
+}
+
+// writeImportReport will respond with the report of an import, with unprocessable entity if any row is invalid.
+func (h *HandlerImp) writeImportReport(w http.ResponseWriter, report *ImportReport, source string) {
+	status := http.StatusOK
+	if len(report.Errors) > 0 {
+		status = http.StatusUnprocessableEntity
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(report)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-%s: %v", source, err)
+	}
+}
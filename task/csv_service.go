@@ -0,0 +1,334 @@
+package task
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ExportTasks will write every task that belongs to or is shared with a user and matches the filter as CSV.
+// The limit of the filter is only the page size used to read the tasks, the export starts after the cursor if there is one.
+func (s *ServiceImp) ExportTasks(tokenString *string, filter *Filter, w io.Writer) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTasks: %v", err)
+		return ErrInvalidToken
+	}
+
+	if filter.Limit < 1 || filter.Limit > MaxLimit {
+		return ErrInvalidFilter
+	}
+
+	tasks, err := s.allTasks(id, filter)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTasks: %v", err)
+		return err
+	}
+
+	lists, err := s.Repository.GetLists(id, true)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTasks: %v", err)
+		return err
+	}
+
+	listNames := make(map[uuid.UUID]string, len(lists))
+	for _, list := range lists {
+		listNames[list.Id] = list.Name
+	}
+
+	err = writeCSV(csv.NewWriter(w), tasks, listNames)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTasks: %v", err)
+	}
+	return err
+}
+
+// allTasks will get every task of a user matching the filter, reading them a page at a time.
+func (s *ServiceImp) allTasks(userId *uuid.UUID, filter *Filter) ([]Task, error) {
+	page := *filter
+	tasks := make([]Task, 0)
+	for {
+		found, err := s.Repository.GetTasks(userId, &page)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(found) <= page.Limit {
+			return append(tasks, found...), nil
+		}
+		tasks = append(tasks, found[:page.Limit]...)
+		page.After = newCursor(&page, &found[page.Limit-1])
+	}
+}
+
+// ImportTasks will validate every row of a CSV file and report the errors with their row and column.
+// The tasks are added in one transaction and only if no row has an error and it is not a dry run.
+func (s *ServiceImp) ImportTasks(tokenString *string, reader io.Reader, options *ImportOptions) (*ImportReport, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTasks: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	csvReader := newCSVReader(reader)
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, importReadError(err)
+	}
+
+	indexes := mapHeader(header, options.Mapping)
+	for _, column := range []string{ColumnName, ColumnPriority, ColumnDueDate} {
+		if _, ok := indexes[column]; !ok {
+			return nil, ErrInvalidImport
+		}
+	}
+
+	validator, err := s.newImportValidator(id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTasks: %v", err)
+		return nil, err
+	}
+
+	report := &ImportReport{DryRun: options.DryRun, Errors: make([]ImportError, 0)}
+	rows := make([]importRow, 0)
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, importReadError(err)
+		}
+
+		report.Rows++
+		if report.Rows > MaxImportRows {
+			return nil, ErrInvalidImport
+		}
+
+		row, rowErrors, err := validator.validate(report.Rows, record, indexes)
+		if err != nil {
+			log.Printf("Error in task-ServiceImp-ImportTasks: %v", err)
+			return nil, err
+		}
+		report.Errors = append(report.Errors, rowErrors...)
+		rows = append(rows, *row)
+	}
+
+	if len(report.Errors) > 0 || options.DryRun {
+		return report, nil
+	}
+
+	err = s.addImportedRows(tokenString, id, rows)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTasks: %v", err)
+		return nil, err
+	}
+
+	report.Imported = len(rows)
+	return report, nil
+}
+
+// addImportedRows will add the validated rows of an import in one transaction.
+func (s *ServiceImp) addImportedRows(tokenString *string, userId *uuid.UUID, rows []importRow) error {
+	return s.Repository.Transaction(func(tx Repository) error {
+		service := *s
+		service.Repository = tx
+
+		for i := range rows {
+			task, _, err := service.AddTask(tokenString, &rows[i].task)
+			if err != nil {
+				return err
+			}
+
+			if len(rows[i].tags) > 0 {
+				err = tx.AttachTags(&task.Id, userId, rows[i].tags)
+				if err != nil {
+					return err
+				}
+			}
+
+			if rows[i].dateCompleted != nil {
+				_, err = service.recordChanges(userId, userId, &task.Id, func(service *ServiceImp) error {
+					_, err := service.Repository.CompleteTask(&task.Id, userId, rows[i].dateCompleted)
+					return err
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// importValidator validates the rows of an import, it remembers the priorities and lists it already resolved.
+type importValidator struct {
+	repository Repository
+	userId     *uuid.UUID
+	lists      []List
+	priorities map[string]*int64
+	owned      map[uuid.UUID]bool
+}
+
+// newImportValidator will create a validator for the rows imported by a user.
+func (s *ServiceImp) newImportValidator(userId *uuid.UUID) (*importValidator, error) {
+	lists, err := s.Repository.GetLists(userId, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &importValidator{
+		repository: s.Repository,
+		userId:     userId,
+		lists:      lists,
+		priorities: make(map[string]*int64),
+		owned:      make(map[uuid.UUID]bool),
+	}, nil
+}
+
+// validate will create a task from a row, the validation errors are returned and the error is only used for failed queries.
+func (v *importValidator) validate(number int, record []string, indexes map[string]int) (*importRow, []ImportError, error) {
+	field := func(column string) string {
+		index, ok := indexes[column]
+		if !ok || index >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[index])
+	}
+
+	row := &importRow{}
+	rowErrors := make([]ImportError, 0)
+	invalid := func(column string, message string) {
+		rowErrors = append(rowErrors, ImportError{Row: number, Column: column, Message: message})
+	}
+
+	row.task.Name = field(ColumnName)
+	if row.task.Name == "" {
+		invalid(ColumnName, "name is required")
+	}
+	row.task.Description = field(ColumnDescription)
+
+	priority, err := v.priority(field(ColumnPriority))
+	if errors.Is(err, ErrInvalidPriority) {
+		invalid(ColumnPriority, "unknown priority")
+	} else if err != nil {
+		return nil, nil, err
+	} else {
+		row.task.Priority = *priority
+	}
+
+	dueDate, err := parseCSVDate(field(ColumnDueDate))
+	if err != nil {
+		invalid(ColumnDueDate, "invalid date")
+	}
+	row.task.DueDate = dueDate
+
+	if value := field(ColumnDateCompleted); value != "" {
+		dateCompleted, err := parseCSVDate(value)
+		if err != nil {
+			invalid(ColumnDateCompleted, "invalid date")
+		}
+		row.dateCompleted = &dateCompleted
+	}
+
+	if value := field(ColumnList); value != "" {
+		listId, err := v.list(value)
+		if errors.Is(err, ErrInvalidList) {
+			invalid(ColumnList, "unknown list")
+		} else if err != nil {
+			return nil, nil, err
+		} else {
+			row.task.ListId = uuid.NullUUID{UUID: *listId, Valid: true}
+		}
+	}
+
+	return row, rowErrors, nil
+}
+
+// priority will resolve a priority written as an id or a name.
+func (v *importValidator) priority(value string) (*int64, error) {
+	if value == "" {
+		return nil, ErrInvalidPriority
+	}
+	if priority, ok := v.priorities[value]; ok {
+		if priority == nil {
+			return nil, ErrInvalidPriority
+		}
+		return priority, nil
+	}
+
+	var priority *int64
+	if id, err := strconv.ParseInt(value, 10, 64); err == nil {
+		ok, err := v.repository.CheckPriority(&id)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			priority = &id
+		}
+	}
+
+	if priority == nil {
+		found, err := v.repository.FindPriority(value)
+		if err != nil && !errors.Is(err, ErrInvalidPriority) {
+			return nil, err
+		}
+		priority = found
+	}
+
+	v.priorities[value] = priority
+	if priority == nil {
+		return nil, ErrInvalidPriority
+	}
+	return priority, nil
+}
+
+// list will resolve a list written as an id or a name, the list must be owned by the user.
+func (v *importValidator) list(value string) (*uuid.UUID, error) {
+	listId := v.findList(value, strings.EqualFold)
+	if listId == nil {
+		return nil, ErrInvalidList
+	}
+
+	owned, err := v.ownsList(listId)
+	if err != nil {
+		return nil, err
+	}
+	if !owned {
+		return nil, ErrInvalidList
+	}
+	return listId, nil
+}
+
+// findList will find a list of the user by its id or by a name matching the value.
+func (v *importValidator) findList(value string, matchName func(string, string) bool) *uuid.UUID {
+	var listId *uuid.UUID
+	for i := range v.lists {
+		if v.lists[i].Id.String() == strings.ToLower(value) {
+			return &v.lists[i].Id
+		}
+		if listId == nil && matchName(v.lists[i].Name, value) {
+			listId = &v.lists[i].Id
+		}
+	}
+	return listId
+}
+
+// ownsList will check if the user owns a list rather than it being shared with them.
+func (v *importValidator) ownsList(listId *uuid.UUID) (bool, error) {
+	owned, ok := v.owned[*listId]
+	if ok {
+		return owned, nil
+	}
+
+	_, err := v.repository.GetList(listId, v.userId)
+	if err != nil && !errors.Is(err, ErrListNotFound) {
+		return false, err
+	}
+	v.owned[*listId] = err == nil
+	return err == nil, nil
+}
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseMapping(t *testing.T) {
+	tests := []struct {
+		value string
+		want  map[string]string
+	}{
+		{"", map[string]string{}},
+		{"Title:name", map[string]string{"Title": "name"}},
+		{"Title:name, Due :dueDate,Folder:list", map[string]string{"Title": "name", "Due": "dueDate", "Folder": "list"}},
+	}
+
+	for _, test := range tests {
+		mapping, err := ParseMapping(test.value)
+		if err != nil {
+			t.Fatalf("ParseMapping(%q) returned %v", test.value, err)
+		}
+		if !reflect.DeepEqual(mapping, test.want) {
+			t.Errorf("ParseMapping(%q) = %v, want %v", test.value, mapping, test.want)
+		}
+	}
+}
+
+func TestParseMappingInvalid(t *testing.T) {
+	tests := []string{
+		"Title",
+		"Title:title",
+		"Id:id",
+		"Title:name,",
+		"Title:Name",
+	}
+
+	for _, value := range tests {
+		_, err := ParseMapping(value)
+		if !errors.Is(err, ErrInvalidImport) {
+			t.Errorf("ParseMapping(%q) returned %v, want %v", value, err, ErrInvalidImport)
+		}
+	}
+}
+
+func TestMapHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  []string
+		mapping map[string]string
+		want    map[string]int
+	}{
+		{
+			name:   "export header",
+			header: exportColumns,
+			want:   map[string]int{"name": 1, "description": 2, "priority": 3, "dueDate": 4, "dateCompleted": 5, "list": 6},
+		},
+		{
+			name:   "case and spaces",
+			header: []string{" NAME ", "DueDate", "Priority", "notes"},
+			want:   map[string]int{"name": 0, "dueDate": 1, "priority": 2},
+		},
+		{
+			name:    "mapped headers",
+			header:  []string{"Title", "Due", "priority", "Folder"},
+			mapping: map[string]string{"Title": "name", "Due": "dueDate", "Folder": "list"},
+			want:    map[string]int{"name": 0, "dueDate": 1, "priority": 2, "list": 3},
+		},
+		{
+			name:    "mapped header wins over a matching name",
+			header:  []string{"Title", "name"},
+			mapping: map[string]string{"Title": "name"},
+			want:    map[string]int{"name": 0},
+		},
+		{
+			name:   "first matching name wins",
+			header: []string{"name", "Name"},
+			want:   map[string]int{"name": 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := mapHeader(test.header, test.mapping)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("mapHeader(%v) = %v, want %v", test.header, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseCSVDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2026-03-01T09:30:00Z", time.Date(2026, time.March, 1, 9, 30, 0, 0, time.UTC)},
+		{"2026-03-01T09:30:00+01:00", time.Date(2026, time.March, 1, 8, 30, 0, 0, time.UTC)},
+		{"2026-03-01", time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		date, err := parseCSVDate(test.value)
+		if err != nil || !date.Equal(test.want) {
+			t.Errorf("parseCSVDate(%q) = %v, %v, want %v", test.value, date, err, test.want)
+		}
+	}
+
+	_, err := parseCSVDate("03/01/2026")
+	if err == nil {
+		t.Error("parseCSVDate(03/01/2026) returned no error")
+	}
+}
+
+func TestImportReadError(t *testing.T) {
+	request := httptest.NewRequest(http.MethodPost, "/tasks/import", strings.NewReader(strings.Repeat("a", MaxImportSize+1)))
+	_, readErr := io.ReadAll(limitImport(httptest.NewRecorder(), request))
+
+	err := importReadError(readErr)
+	var maxBytesError *http.MaxBytesError
+	if !errors.Is(err, ErrInvalidImport) || !errors.As(err, &maxBytesError) {
+		t.Errorf("importReadError(%v) = %v, want an invalid import keeping the size error", readErr, err)
+	}
+}
@@ -0,0 +1,34 @@
+package task
+
+import "github.com/google/uuid"
+
+// Dependency is an edge of the dependency graph, the task is blocked by the blocker until it is completed.
+type Dependency struct {
+	TaskId    uuid.UUID `json:"taskId"`
+	BlockerId uuid.UUID `json:"blockerId"`
+}
+
+// createsCycle will check if adding an edge to the dependency graph closes a cycle,
+// which is the case if the task already blocks the blocker directly or through other tasks.
+func createsCycle(dependencies []Dependency, edge Dependency) bool {
+	blockers := make(map[uuid.UUID][]uuid.UUID)
+	for _, dependency := range dependencies {
+		blockers[dependency.TaskId] = append(blockers[dependency.TaskId], dependency.BlockerId)
+	}
+
+	visited := make(map[uuid.UUID]bool)
+	pending := []uuid.UUID{edge.BlockerId}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if current == edge.TaskId {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		pending = append(pending, blockers[current]...)
+	}
+	return false
+}
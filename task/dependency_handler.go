@@ -0,0 +1,137 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// handleTaskBlocked will respond each time a task with open blockers is completed without force.
+func (h *HandlerImp) handleTaskBlocked(w http.ResponseWriter) {
+	http.Error(w, "Task is blocked", http.StatusConflict)
+}
+
+// handleInvalidDependency will respond each time a blocker is not a task of the owner of the blocked task.
+func (h *HandlerImp) handleInvalidDependency(w http.ResponseWriter) {
+	http.Error(w, "Invalid dependency", http.StatusBadRequest)
+}
+
+// handleDependencyCycle will respond each time a dependency would make a task block itself.
+func (h *HandlerImp) handleDependencyCycle(w http.ResponseWriter) {
+	http.Error(w, "Dependency would create a cycle", http.StatusConflict)
+}
+
+// handleDependencyNotFound will respond each time a removed dependency does not exist.
+func (h *HandlerImp) handleDependencyNotFound(w http.ResponseWriter) {
+	http.Error(w, "Dependency not found", http.StatusNotFound)
+}
+
+// handleDependency will handle the post requests that add or remove the dependency of the task id on the blocker id.
+func (h *HandlerImp) handleDependency(w http.ResponseWriter, r *http.Request, change func(*string, *uuid.UUID, *uuid.UUID) (*Task, error)) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	blockerId, err := uuid.Parse(r.URL.Query().Get("blocker"))
+	if err != nil {
+		h.handleInvalidDependency(w)
+		return
+	}
+
+	task, err := change(&token, &id, &blockerId)
+	if errors.Is(err, ErrInvalidDependency) {
+		h.handleInvalidDependency(w)
+		return
+	} else if errors.Is(err, ErrDependencyCycle) {
+		h.handleDependencyCycle(w)
+		return
+	} else if errors.Is(err, ErrDependencyNotFound) {
+		h.handleDependencyNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-handleDependency: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeTask(w, task)
+}
+
+// HandleAddDependency will handle post requests making a task blocked by another task.
+func (h *HandlerImp) HandleAddDependency(w http.ResponseWriter, r *http.Request) {
+	h.handleDependency(w, r, h.Service.AddDependency)
+}
+
+// HandleRemoveDependency will handle post requests stopping a task from being blocked by another task.
+func (h *HandlerImp) HandleRemoveDependency(w http.ResponseWriter, r *http.Request) {
+	h.handleDependency(w, r, h.Service.RemoveDependency)
+}
+
+// HandleGetBlockers will handle get requests for the tasks blocking a task.
+func (h *HandlerImp) HandleGetBlockers(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	tasks, err := h.Service.GetBlockers(&token, &id)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetBlockers: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(tasks)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetBlockers: %v", err)
+	}
+}
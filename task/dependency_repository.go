@@ -0,0 +1,105 @@
+package task
+
+import (
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// GetDependencies will get every edge of the dependency graph of the tasks of a user.
+func (r *PostgresRepository) GetDependencies(userId *uuid.UUID) ([]Dependency, error) {
+	query := "SELECT td.task_id, td.blocker_id FROM task_dependencies td JOIN tasks t ON t.id = td.task_id WHERE t.user_id = $1"
+	log.Printf("Executing query in task-PostgresRepository-GetDependencies: %s | Parameters %s", query, userId)
+
+	rows, err := r.database.Query(query, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetDependencies: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	dependencies := make([]Dependency, 0)
+	for rows.Next() {
+		var dependency Dependency
+		err := rows.Scan(&dependency.TaskId, &dependency.BlockerId)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetDependencies: %v", err)
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetDependencies: %v", err)
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// AddDependency will add an edge to the dependency graph, adding an existing edge does nothing.
+func (r *PostgresRepository) AddDependency(dependency *Dependency) error {
+	query := "INSERT INTO task_dependencies(task_id, blocker_id) VALUES ($1, $2) ON CONFLICT DO NOTHING"
+	log.Printf("Executing query in task-PostgresRepository-AddDependency: %s | Parameters %s, %s", query, dependency.TaskId, dependency.BlockerId)
+
+	_, err := r.database.Exec(query, dependency.TaskId, dependency.BlockerId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddDependency: %v", err)
+	}
+	return err
+}
+
+// RemoveDependency will remove an edge from the dependency graph.
+// It returns ErrDependencyNotFound if there is no such edge.
+func (r *PostgresRepository) RemoveDependency(dependency *Dependency) error {
+	query := "DELETE FROM task_dependencies WHERE task_id = $1 AND blocker_id = $2"
+	log.Printf("Executing query in task-PostgresRepository-RemoveDependency: %s | Parameters %s, %s", query, dependency.TaskId, dependency.BlockerId)
+
+	result, err := r.database.Exec(query, dependency.TaskId, dependency.BlockerId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-RemoveDependency: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-RemoveDependency: %v", err)
+		return err
+	}
+
+	if count == 0 {
+		return ErrDependencyNotFound
+	}
+	return nil
+}
+
+// GetBlockers will get the tasks blocking a task owned by a user that are not in the trash, ordered by due date.
+func (r *PostgresRepository) GetBlockers(taskId *uuid.UUID, userId *uuid.UUID) ([]Task, error) {
+	query := "SELECT " + selectTaskColumns("tasks") + " FROM tasks WHERE id IN (SELECT blocker_id FROM task_dependencies WHERE task_id = $1) " +
+		"AND user_id = $2 AND date_deleted IS NULL ORDER BY due_date, id"
+	log.Printf("Executing query in task-PostgresRepository-GetBlockers: %s | Parameters %s, %s", query, taskId, userId)
+
+	rows, err := r.database.Query(query, *taskId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetBlockers: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]Task, 0)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetBlockers: %v", err)
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetBlockers: %v", err)
+		return nil, err
+	}
+	return tasks, nil
+}
@@ -0,0 +1,168 @@
+package task
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// checkUnblocked will return ErrTaskBlocked if an open task has blockers that are neither completed nor in the trash.
+// It must run inside a transaction, the owner stays locked until it ends so no blocker is added before the task is completed.
+func (s *ServiceImp) checkUnblocked(taskId *uuid.UUID, ownerId *uuid.UUID) error {
+	err := s.Repository.LockUser(ownerId)
+	if err != nil {
+		return err
+	}
+
+	task, err := s.Repository.GetTask(taskId, ownerId)
+	if err != nil {
+		return err
+	}
+
+	if task.Blocked && !task.DateCompleted.Valid {
+		return ErrTaskBlocked
+	}
+	return nil
+}
+
+// dependencyEntry will create the history entry of a dependency added to or removed from the task it blocks.
+// The edge is not a field of the task, so it is recorded as a change of the blockedBy pseudo field.
+func dependencyEntry(actorId *uuid.UUID, dependency *Dependency, added bool) HistoryEntry {
+	change := Change{Field: "blockedBy", Old: dependency.BlockerId}
+	if added {
+		change = Change{Field: "blockedBy", New: dependency.BlockerId}
+	}
+
+	return HistoryEntry{
+		Id:          uuid.New(),
+		TaskId:      dependency.TaskId,
+		ActorId:     *actorId,
+		Action:      ActionUpdate,
+		Changes:     []Change{change},
+		DateCreated: time.Now().UTC(),
+	}
+}
+
+// AddDependency will make a task blocked by another task of the same owner and return the updated task.
+// An edge that would close a cycle in the dependency graph is rejected with ErrDependencyCycle.
+func (s *ServiceImp) AddDependency(tokenString *string, taskId *uuid.UUID, blockerId *uuid.UUID) (*Task, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddDependency: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddDependency: %v", err)
+		return nil, err
+	}
+
+	_, err = s.Repository.GetTask(blockerId, ownerId)
+	if errors.Is(err, ErrTaskNotFound) {
+		return nil, ErrInvalidDependency
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-AddDependency: %v", err)
+		return nil, err
+	}
+
+	var task *Task
+	err = s.Repository.Transaction(func(tx Repository) error {
+		// concurrent edges could each pass the cycle check and close a cycle together, the owner is locked first
+		err := tx.LockUser(ownerId)
+		if err != nil {
+			return err
+		}
+
+		dependencies, err := tx.GetDependencies(ownerId)
+		if err != nil {
+			return err
+		}
+
+		dependency := Dependency{TaskId: *taskId, BlockerId: *blockerId}
+		if createsCycle(dependencies, dependency) {
+			return ErrDependencyCycle
+		}
+
+		err = tx.AddDependency(&dependency)
+		if err != nil {
+			return err
+		}
+
+		err = tx.AddHistory([]HistoryEntry{dependencyEntry(id, &dependency, true)})
+		if err != nil {
+			return err
+		}
+
+		task, err = tx.GetTask(taskId, ownerId)
+		return err
+	})
+	if errors.Is(err, ErrDependencyCycle) {
+		return nil, err
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-AddDependency: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// RemoveDependency will stop a task from being blocked by another task and return the updated task.
+func (s *ServiceImp) RemoveDependency(tokenString *string, taskId *uuid.UUID, blockerId *uuid.UUID) (*Task, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-RemoveDependency: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-RemoveDependency: %v", err)
+		return nil, err
+	}
+
+	var task *Task
+	err = s.Repository.Transaction(func(tx Repository) error {
+		dependency := Dependency{TaskId: *taskId, BlockerId: *blockerId}
+		err := tx.RemoveDependency(&dependency)
+		if err != nil {
+			return err
+		}
+
+		err = tx.AddHistory([]HistoryEntry{dependencyEntry(id, &dependency, false)})
+		if err != nil {
+			return err
+		}
+
+		task, err = tx.GetTask(taskId, ownerId)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-RemoveDependency: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetBlockers will return the tasks blocking a task, completed blockers are included.
+func (s *ServiceImp) GetBlockers(tokenString *string, taskId *uuid.UUID) ([]Task, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetBlockers: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleViewer)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetBlockers: %v", err)
+		return nil, err
+	}
+
+	tasks, err := s.Repository.GetBlockers(taskId, ownerId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetBlockers: %v", err)
+		return nil, err
+	}
+	return tasks, nil
+}
@@ -0,0 +1,62 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreatesCycle(t *testing.T) {
+	a, b, c, d := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	// a is blocked by b, b by c, and d by c
+	dependencies := []Dependency{
+		{TaskId: a, BlockerId: b},
+		{TaskId: b, BlockerId: c},
+		{TaskId: d, BlockerId: c},
+	}
+
+	tests := []struct {
+		name string
+		edge Dependency
+		want bool
+	}{
+		{"self", Dependency{TaskId: a, BlockerId: a}, true},
+		{"direct", Dependency{TaskId: b, BlockerId: a}, true},
+		{"transitive", Dependency{TaskId: c, BlockerId: a}, true},
+		{"existing edge", Dependency{TaskId: a, BlockerId: b}, false},
+		{"shortcut", Dependency{TaskId: a, BlockerId: c}, false},
+		{"shared blocker", Dependency{TaskId: a, BlockerId: d}, false},
+		{"other branch", Dependency{TaskId: d, BlockerId: a}, false},
+		{"new task", Dependency{TaskId: c, BlockerId: uuid.New()}, false},
+	}
+
+	for _, test := range tests {
+		got := createsCycle(dependencies, test.edge)
+		if got != test.want {
+			t.Errorf("createsCycle(%s) = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCreatesCycleEmpty(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	if createsCycle(nil, Dependency{TaskId: a, BlockerId: b}) {
+		t.Error("createsCycle without dependencies = true, want false")
+	}
+}
+
+func TestDependencyEntry(t *testing.T) {
+	actorId := uuid.New()
+	dependency := Dependency{TaskId: uuid.New(), BlockerId: uuid.New()}
+
+	added := dependencyEntry(&actorId, &dependency, true)
+	if added.TaskId != dependency.TaskId || added.ActorId != actorId || added.Action != ActionUpdate ||
+		len(added.Changes) != 1 || added.Changes[0] != (Change{Field: "blockedBy", New: dependency.BlockerId}) {
+		t.Errorf("entry of an added dependency = %+v", added)
+	}
+
+	removed := dependencyEntry(&actorId, &dependency, false)
+	if len(removed.Changes) != 1 || removed.Changes[0] != (Change{Field: "blockedBy", Old: dependency.BlockerId}) {
+		t.Errorf("entry of a removed dependency = %+v", removed)
+	}
+}
@@ -3,6 +3,35 @@ package task
 import "errors"
 
 var (
-	ErrInvalidPriority = errors.New("invalid priority")
-	ErrInvalidToken    = errors.New("invalid token")
+	ErrInvalidPriority    = errors.New("invalid priority")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrInvalidFilter      = errors.New("invalid filter")
+	ErrInvalidCursor      = errors.New("invalid cursor")
+	ErrTaskNotFound       = errors.New("task not found")
+	ErrInvalidPatch       = errors.New("invalid patch")
+	ErrVersionMismatch    = errors.New("version mismatch")
+	ErrInvalidRecurrence  = errors.New("invalid recurrence")
+	ErrInvalidParent      = errors.New("invalid parent")
+	ErrMaxDepthExceeded   = errors.New("max depth exceeded")
+	ErrInvalidTag         = errors.New("invalid tag")
+	ErrTagNotFound        = errors.New("tag not found")
+	ErrTagNameInUse       = errors.New("tag name is already in use")
+	ErrInvalidList        = errors.New("invalid list")
+	ErrListNotFound       = errors.New("list not found")
+	ErrForbidden          = errors.New("role does not allow the action")
+	ErrInvalidShare       = errors.New("invalid share")
+	ErrShareNotFound      = errors.New("share not found")
+	ErrShareExists        = errors.New("user already has a share")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidBulk        = errors.New("invalid bulk request")
+	ErrInvalidImport      = errors.New("invalid import")
+	ErrFeedNotFound       = errors.New("calendar feed not found")
+	ErrUndoNotFound       = errors.New("undo not found")
+	ErrUndoConflict       = errors.New("task changed since")
+	ErrInvalidRank        = errors.New("invalid rank")
+	ErrInvalidDependency  = errors.New("invalid dependency")
+	ErrDependencyCycle    = errors.New("dependency would create a cycle")
+	ErrDependencyNotFound = errors.New("dependency not found")
+	ErrTaskBlocked        = errors.New("task has open blockers")
+	ErrInvalidEstimate    = errors.New("invalid estimate")
 )
@@ -0,0 +1,40 @@
+package task
+
+import "database/sql"
+
+// MaxEstimate is the biggest value an estimate can have in either unit.
+const MaxEstimate = 100000
+
+// EstimateUnit is what the value of an estimate counts.
+type EstimateUnit string
+
+const (
+	EstimateMinutes EstimateUnit = "minutes"
+	EstimatePoints  EstimateUnit = "points"
+)
+
+// Estimate is the effort a task is expected to take.
+type Estimate struct {
+	Value int64        `json:"value"`
+	Unit  EstimateUnit `json:"unit"`
+}
+
+// valid will check if an estimate has a known unit and a value in range, a nil estimate is valid.
+func (e *Estimate) valid() bool {
+	if e == nil {
+		return true
+	}
+	return (e.Unit == EstimateMinutes || e.Unit == EstimatePoints) && e.Value >= 0 && e.Value <= MaxEstimate
+}
+
+// values will return the values of the estimate columns, both of them are null for a nil estimate.
+func (e *Estimate) values() []any {
+	if e == nil {
+		return []any{sql.NullInt64{}, sql.NullString{}}
+	}
+
+	return []any{
+		sql.NullInt64{Int64: e.Value, Valid: true},
+		sql.NullString{String: string(e.Unit), Valid: true},
+	}
+}
@@ -0,0 +1,26 @@
+package task
+
+import "testing"
+
+func TestEstimateValid(t *testing.T) {
+	tests := []struct {
+		estimate *Estimate
+		want     bool
+	}{
+		{nil, true},
+		{&Estimate{Value: 0, Unit: EstimateMinutes}, true},
+		{&Estimate{Value: 90, Unit: EstimateMinutes}, true},
+		{&Estimate{Value: MaxEstimate, Unit: EstimatePoints}, true},
+		{&Estimate{Value: MaxEstimate + 1, Unit: EstimatePoints}, false},
+		{&Estimate{Value: -1, Unit: EstimateMinutes}, false},
+		{&Estimate{Value: 3, Unit: "hours"}, false},
+		{&Estimate{Value: 3}, false},
+	}
+
+	for _, test := range tests {
+		got := test.estimate.valid()
+		if got != test.want {
+			t.Errorf("%+v.valid() = %t, want %t", test.estimate, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package task
+
+import (
+	"database/sql"
+	"maps"
+	"slices"
+	"task-server/middleware"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeAuthenticator accepts every access token that is the id of a user.
+type fakeAuthenticator struct {
+	middleware.Authenticator
+}
+
+// CheckAccessToken will return the user id the token is made of.
+func (a fakeAuthenticator) CheckAccessToken(token *string) (*uuid.UUID, error) {
+	id, err := uuid.Parse(*token)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// memoryTask is a stored task with the user owning it.
+type memoryTask struct {
+	Task
+	UserId uuid.UUID
+}
+
+// memoryList is a stored list with the user owning it.
+type memoryList struct {
+	List
+	UserId uuid.UUID
+}
+
+// memoryRepository is a Repository keeping everything in memory, following the queries of PostgresRepository.
+// Only the methods the tests use are implemented, the others panic through the nil embedded interface.
+type memoryRepository struct {
+	Repository
+	tasks        map[uuid.UUID]memoryTask
+	lists        map[uuid.UUID]memoryList
+	dependencies []Dependency
+	history      []HistoryEntry
+	undos        []Undo
+}
+
+// newMemoryRepository will create an empty repository.
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		tasks: make(map[uuid.UUID]memoryTask),
+		lists: make(map[uuid.UUID]memoryList),
+	}
+}
+
+// Transaction will call fn and put every stored value back if it fails, savepoints nest the same way.
+func (r *memoryRepository) Transaction(fn func(Repository) error) error {
+	tasks := maps.Clone(r.tasks)
+	lists := maps.Clone(r.lists)
+	dependencies := slices.Clone(r.dependencies)
+	history := slices.Clone(r.history)
+	undos := slices.Clone(r.undos)
+
+	err := fn(r)
+	if err != nil {
+		r.tasks, r.lists, r.dependencies, r.history, r.undos = tasks, lists, dependencies, history, undos
+	}
+	return err
+}
+
+// LockUser does nothing, the tests run one change at a time.
+func (r *memoryRepository) LockUser(*uuid.UUID) error {
+	return nil
+}
+
+// read will return a stored task with its computed blocked flag.
+func (r *memoryRepository) read(stored memoryTask) *Task {
+	task := stored.Task
+	task.Blocked = false
+	for _, dependency := range r.dependencies {
+		blocker, ok := r.tasks[dependency.BlockerId]
+		if dependency.TaskId == task.Id && ok && !blocker.DateCompleted.Valid && !blocker.DateDeleted.Valid {
+			task.Blocked = true
+		}
+	}
+	return &task
+}
+
+// live will get a task owned by a user that is not in the trash.
+func (r *memoryRepository) live(taskId *uuid.UUID, userId *uuid.UUID) (memoryTask, bool) {
+	stored, ok := r.tasks[*taskId]
+	return stored, ok && stored.UserId == *userId && !stored.DateDeleted.Valid
+}
+
+// descendants will return the ids of all descendants of a task, including the tasks in the trash.
+func (r *memoryRepository) descendants(taskId uuid.UUID) []uuid.UUID {
+	ids := make([]uuid.UUID, 0)
+	found := map[uuid.UUID]bool{taskId: true}
+	for i := -1; i < len(ids); i++ {
+		parent := taskId
+		if i >= 0 {
+			parent = ids[i]
+		}
+		for id, stored := range r.tasks {
+			if stored.ParentId.Valid && stored.ParentId.UUID == parent && !found[id] {
+				found[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func (r *memoryRepository) CheckPriority(priority *int64) (bool, error) {
+	return *priority >= 1 && *priority <= 3, nil
+}
+
+func (r *memoryRepository) GetTaskAccess(taskId *uuid.UUID, userId *uuid.UUID) (*uuid.UUID, Role, error) {
+	stored, ok := r.tasks[*taskId]
+	if !ok || stored.DateDeleted.Valid || stored.UserId != *userId {
+		return nil, "", ErrTaskNotFound
+	}
+	return &stored.UserId, RoleOwner, nil
+}
+
+func (r *memoryRepository) GetTask(taskId *uuid.UUID, userId *uuid.UUID) (*Task, error) {
+	stored, ok := r.live(taskId, userId)
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	return r.read(stored), nil
+}
+
+func (r *memoryRepository) AddTask(task *Task, userId *uuid.UUID) error {
+	r.tasks[task.Id] = memoryTask{Task: *task, UserId: *userId}
+	return nil
+}
+
+func (r *memoryRepository) UpdateTask(task *Task, userId *uuid.UUID, version *int64) (*Task, error) {
+	stored, ok := r.live(&task.Id, userId)
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	if version != nil && stored.Version != *version {
+		return nil, ErrVersionMismatch
+	}
+
+	stored.Name, stored.Description, stored.Priority = task.Name, task.Description, task.Priority
+	stored.DueDate, stored.DateCompleted, stored.Estimate = task.DueDate, task.DateCompleted, task.Estimate
+	stored.Version++
+	r.tasks[task.Id] = stored
+	return r.read(stored), nil
+}
+
+func (r *memoryRepository) DeleteTask(taskId *uuid.UUID, userId *uuid.UUID, dateDeleted *time.Time, version *int64) error {
+	stored, ok := r.live(taskId, userId)
+	if !ok {
+		return ErrTaskNotFound
+	}
+	if version != nil && stored.Version != *version {
+		return ErrVersionMismatch
+	}
+
+	stored.DateDeleted = NullTime{sqlTime(*dateDeleted)}
+	stored.Version++
+	r.tasks[*taskId] = stored
+	return nil
+}
+
+func (r *memoryRepository) CompleteTask(taskId *uuid.UUID, userId *uuid.UUID, dateCompleted *time.Time) (*Task, error) {
+	stored, ok := r.live(taskId, userId)
+	if !ok || stored.DateCompleted.Valid {
+		return nil, ErrTaskNotFound
+	}
+
+	stored.DateCompleted = NullTime{sqlTime(*dateCompleted)}
+	stored.Version++
+	r.tasks[*taskId] = stored
+	return r.read(stored), nil
+}
+
+func (r *memoryRepository) UncompleteTask(taskId *uuid.UUID, userId *uuid.UUID) (*Task, error) {
+	stored, ok := r.live(taskId, userId)
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	stored.DateCompleted = NullTime{}
+	stored.Version++
+	r.tasks[*taskId] = stored
+	return r.read(stored), nil
+}
+
+func (r *memoryRepository) CompleteDescendants(taskId *uuid.UUID, userId *uuid.UUID, dateCompleted *time.Time) error {
+	for _, id := range r.descendants(*taskId) {
+		stored := r.tasks[id]
+		if !stored.DateCompleted.Valid && !stored.DateDeleted.Valid {
+			stored.DateCompleted = NullTime{sqlTime(*dateCompleted)}
+			stored.Version++
+			r.tasks[id] = stored
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) DeleteDescendants(taskId *uuid.UUID, userId *uuid.UUID, dateDeleted *time.Time) error {
+	for _, id := range r.descendants(*taskId) {
+		stored := r.tasks[id]
+		if !stored.DateDeleted.Valid {
+			stored.DateDeleted = NullTime{sqlTime(*dateDeleted)}
+			stored.Version++
+			r.tasks[id] = stored
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) ReparentChildren(taskId *uuid.UUID, userId *uuid.UUID) error {
+	parentId := r.tasks[*taskId].ParentId
+	for id, stored := range r.tasks {
+		if stored.ParentId.Valid && stored.ParentId.UUID == *taskId && stored.UserId == *userId {
+			stored.ParentId = parentId
+			stored.Version++
+			r.tasks[id] = stored
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) GetSubtree(taskId *uuid.UUID, userId *uuid.UUID) ([]Task, error) {
+	root, ok := r.live(taskId, userId)
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	subtree := []Task{*r.read(root)}
+	for i := 0; i < len(subtree); i++ {
+		for _, stored := range r.tasks {
+			if stored.ParentId.Valid && stored.ParentId.UUID == subtree[i].Id && !stored.DateDeleted.Valid {
+				subtree = append(subtree, *r.read(stored))
+			}
+		}
+	}
+	return subtree, nil
+}
+
+func (r *memoryRepository) MoveTask(taskId *uuid.UUID, userId *uuid.UUID, parentId *uuid.NullUUID) (*Task, error) {
+	stored, ok := r.live(taskId, userId)
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	stored.ParentId = *parentId
+	stored.Version++
+	r.tasks[*taskId] = stored
+	return r.read(stored), nil
+}
+
+func (r *memoryRepository) GetDepth(taskId *uuid.UUID, userId *uuid.UUID) (int, error) {
+	stored, ok := r.live(taskId, userId)
+	if !ok {
+		return 0, ErrTaskNotFound
+	}
+
+	depth := 1
+	for stored.ParentId.Valid {
+		stored = r.tasks[stored.ParentId.UUID]
+		depth++
+	}
+	return depth, nil
+}
+
+func (r *memoryRepository) GetLastRank(userId *uuid.UUID) (string, error) {
+	last := ""
+	for _, stored := range r.tasks {
+		if stored.UserId == *userId && stored.Rank > last {
+			last = stored.Rank
+		}
+	}
+	return last, nil
+}
+
+func (r *memoryRepository) GetTaskFamily(taskId *uuid.UUID, userId *uuid.UUID) ([]Task, error) {
+	root, ok := r.tasks[*taskId]
+	if !ok || root.UserId != *userId {
+		return []Task{}, nil
+	}
+
+	family := map[uuid.UUID]bool{*taskId: true}
+	for _, id := range r.descendants(*taskId) {
+		family[id] = true
+	}
+	if root.Recurrence != nil {
+		for id, stored := range r.tasks {
+			if stored.Recurrence != nil && stored.Recurrence.SeriesId == root.Recurrence.SeriesId {
+				family[id] = true
+			}
+		}
+	}
+
+	tasks := make([]Task, 0, len(family))
+	for id := range family {
+		tasks = append(tasks, *r.read(r.tasks[id]))
+	}
+	return tasks, nil
+}
+
+func (r *memoryRepository) AddHistory(entries []HistoryEntry) error {
+	r.history = append(r.history, entries...)
+	return nil
+}
+
+func (r *memoryRepository) AddUndo(undo *Undo) error {
+	r.undos = append(r.undos, *undo)
+	return nil
+}
+
+func (r *memoryRepository) GetList(listId *uuid.UUID, userId *uuid.UUID) (*List, error) {
+	stored, ok := r.lists[*listId]
+	if !ok || stored.UserId != *userId {
+		return nil, ErrListNotFound
+	}
+	return &stored.List, nil
+}
+
+func (r *memoryRepository) GetInbox(userId *uuid.UUID) (*List, error) {
+	for _, stored := range r.lists {
+		if stored.UserId == *userId && stored.Inbox {
+			return &stored.List, nil
+		}
+	}
+	return nil, ErrListNotFound
+}
+
+func (r *memoryRepository) AddList(list *List, userId *uuid.UUID) error {
+	r.lists[list.Id] = memoryList{List: *list, UserId: *userId}
+	return nil
+}
+
+func (r *memoryRepository) AddInbox(list *List, userId *uuid.UUID) error {
+	_, err := r.GetInbox(userId)
+	if err == nil {
+		return nil
+	}
+	return r.AddList(list, userId)
+}
+
+func (r *memoryRepository) GetListTasks(listId *uuid.UUID, userId *uuid.UUID) ([]Task, error) {
+	tasks := make([]Task, 0)
+	for _, stored := range r.tasks {
+		if stored.ListId == *listId && stored.UserId == *userId {
+			tasks = append(tasks, *r.read(stored))
+		}
+	}
+	return tasks, nil
+}
+
+func (r *memoryRepository) DeleteList(listId *uuid.UUID, userId *uuid.UUID, targetId *uuid.UUID) error {
+	for id, stored := range r.tasks {
+		if stored.ListId == *listId && stored.UserId == *userId {
+			stored.ListId = *targetId
+			stored.Version++
+			r.tasks[id] = stored
+		}
+	}
+	delete(r.lists, *listId)
+	return nil
+}
+
+// sqlTime will wrap a time in a valid nullable time.
+func sqlTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: true}
+}
+
+// newFakeService will create a service on a repository that accepts user ids as access tokens.
+func newFakeService(repository Repository) *ServiceImp {
+	service := NewServiceImp(repository, fakeAuthenticator{}, 3, time.Hour, Capacity{})
+	return &service
+}
+
+// addTask will store an open task of a user, the inbox of the user is created for it.
+func (r *memoryRepository) addTask(userId uuid.UUID, name string, parent *Task) Task {
+	inbox, err := r.GetInbox(&userId)
+	if err != nil {
+		inbox = &List{Id: uuid.New(), Name: InboxName, Inbox: true}
+		r.AddList(inbox, &userId)
+	}
+
+	task := Task{
+		Id:       uuid.New(),
+		Name:     name,
+		Priority: 1,
+		DueDate:  time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC),
+		Version:  1,
+		ListId:   inbox.Id,
+		Tags:     []Tag{},
+	}
+	if parent != nil {
+		task.ParentId = uuid.NullUUID{UUID: parent.Id, Valid: true}
+		task.ListId = parent.ListId
+	}
+	r.AddTask(&task, &userId)
+	return task
+}
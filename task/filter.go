@@ -0,0 +1,219 @@
+package task
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultLimit is the page size used when the client does not specify one.
+	DefaultLimit = 100
+	// MaxLimit is the biggest page size a client can request.
+	MaxLimit = 500
+)
+
+// Status is used to filter tasks by their state.
+// StatusAny matches every task that is not in the trash.
+type Status string
+
+const (
+	StatusAny       Status = ""
+	StatusActive    Status = "active"
+	StatusCompleted Status = "completed"
+	StatusDeleted   Status = "deleted"
+)
+
+// SortField is the field used to order tasks.
+type SortField string
+
+const (
+	SortDueDate  SortField = "dueDate"
+	SortPriority SortField = "priority"
+	SortName     SortField = "name"
+	// SortRank is the manual order the user dragged the tasks into.
+	SortRank SortField = "rank"
+)
+
+// Filter holds the options used when listing tasks.
+type Filter struct {
+	Status   Status
+	Priority *int64
+	DueFrom  *time.Time
+	DueTo    *time.Time
+	// ListId will match the tasks of a list, when it is nil the tasks of archived lists are hidden.
+	ListId *uuid.UUID
+	// Tags will match the tasks having any of the tags, or all of them if MatchAllTags is true.
+	Tags         []uuid.UUID
+	MatchAllTags bool
+	Sort         SortField
+	Descending   bool
+	Limit        int
+	// After is the decoded cursor, the listing will start after it.
+	After *Cursor
+}
+
+// Page is a single page of tasks.
+type Page struct {
+	Tasks []Task `json:"tasks"`
+	Next  string `json:"next,omitempty"`
+}
+
+// Cursor is the position of the last task of a page.
+type Cursor struct {
+	Sort       SortField `json:"s"`
+	Descending bool      `json:"d,omitempty"`
+	DueDate    time.Time `json:"dd,omitempty"`
+	Priority   int64     `json:"p,omitempty"`
+	Name       string    `json:"n,omitempty"`
+	Rank       string    `json:"r,omitempty"`
+	Id         uuid.UUID `json:"id"`
+}
+
+// newCursor will create a cursor pointing after a task.
+func newCursor(filter *Filter, task *Task) *Cursor {
+	return &Cursor{
+		Sort:       filter.Sort,
+		Descending: filter.Descending,
+		DueDate:    task.DueDate,
+		Priority:   task.Priority,
+		Name:       task.Name,
+		Rank:       task.Rank,
+		Id:         task.Id,
+	}
+}
+
+// Encode will encode the cursor into an opaque string.
+func (c *Cursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeCursor will decode a cursor created by Cursor.Encode.
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// ParseFilter will create a filter from the query parameters of a request.
+func ParseFilter(values url.Values) (*Filter, error) {
+	filter := &Filter{
+		Status: Status(values.Get("status")),
+		Sort:   SortField(values.Get("sort")),
+		Limit:  DefaultLimit,
+	}
+
+	switch filter.Status {
+	case StatusAny, StatusActive, StatusCompleted, StatusDeleted:
+	default:
+		return nil, ErrInvalidFilter
+	}
+
+	switch filter.Sort {
+	case "":
+		filter.Sort = SortDueDate
+	case SortDueDate, SortPriority, SortName, SortRank:
+	default:
+		return nil, ErrInvalidFilter
+	}
+
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		return nil, ErrInvalidFilter
+	}
+
+	if value := values.Get("priority"); value != "" {
+		priority, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.Priority = &priority
+	}
+
+	if value := values.Get("dueFrom"); value != "" {
+		dueFrom, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.DueFrom = &dueFrom
+	}
+
+	if value := values.Get("dueTo"); value != "" {
+		dueTo, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.DueTo = &dueTo
+	}
+
+	if value := values.Get("list"); value != "" {
+		listId, err := uuid.Parse(value)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.ListId = &listId
+	}
+
+	if value := values.Get("tags"); value != "" {
+		for _, part := range strings.Split(value, ",") {
+			tagId, err := uuid.Parse(part)
+			if err != nil {
+				return nil, ErrInvalidFilter
+			}
+			if !slices.Contains(filter.Tags, tagId) {
+				filter.Tags = append(filter.Tags, tagId)
+			}
+		}
+	}
+
+	switch values.Get("tagMatch") {
+	case "", "any":
+	case "all":
+		filter.MatchAllTags = true
+	default:
+		return nil, ErrInvalidFilter
+	}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return nil, ErrInvalidFilter
+		}
+		filter.Limit = limit
+	}
+
+	if value := values.Get("cursor"); value != "" {
+		cursor, err := DecodeCursor(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if cursor.Sort != filter.Sort || cursor.Descending != filter.Descending {
+			return nil, ErrInvalidCursor
+		}
+		filter.After = cursor
+	}
+
+	return filter, nil
+}
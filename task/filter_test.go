@@ -0,0 +1,142 @@
+package task
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseFilter(t *testing.T) {
+	priority := int64(2)
+	dueFrom := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	listId := uuid.MustParse("4b7a5a3e-7b5a-4d8e-9f1a-0c6d2e3f4a5b")
+	tagA := uuid.MustParse("0f0e4a52-1c9d-4f5b-8e47-9a1b2c3d4e5f")
+	tagB := uuid.MustParse("7d2c1b0a-9e8f-4a6b-b5c4-d3e2f1a0b9c8")
+
+	tests := []struct {
+		name  string
+		query string
+		want  Filter
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  Filter{Sort: SortDueDate, Limit: DefaultLimit},
+		},
+		{
+			name:  "status, sort and order",
+			query: "status=completed&sort=priority&order=desc&limit=10",
+			want:  Filter{Status: StatusCompleted, Sort: SortPriority, Descending: true, Limit: 10},
+		},
+		{
+			name:  "priority, due date and list",
+			query: "priority=2&dueFrom=2026-01-01T00:00:00Z&list=" + listId.String(),
+			want:  Filter{Priority: &priority, DueFrom: &dueFrom, ListId: &listId, Sort: SortDueDate, Limit: DefaultLimit},
+		},
+		{
+			name:  "tags without duplicates",
+			query: "tags=" + tagA.String() + "," + tagB.String() + "," + tagA.String() + "&tagMatch=all",
+			want:  Filter{Tags: []uuid.UUID{tagA, tagB}, MatchAllTags: true, Sort: SortDueDate, Limit: DefaultLimit},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			values, err := url.ParseQuery(test.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			filter, err := ParseFilter(values)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) returned %v", test.query, err)
+			}
+			if !reflect.DeepEqual(*filter, test.want) {
+				t.Errorf("ParseFilter(%q) = %+v, want %+v", test.query, *filter, test.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterInvalid(t *testing.T) {
+	cursor, err := (&Cursor{Sort: SortName, Id: uuid.New()}).Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		want  error
+	}{
+		{"status=archived", ErrInvalidFilter},
+		{"sort=color", ErrInvalidFilter},
+		{"order=up", ErrInvalidFilter},
+		{"priority=high", ErrInvalidFilter},
+		{"dueFrom=2026-01-01", ErrInvalidFilter},
+		{"dueTo=tomorrow", ErrInvalidFilter},
+		{"list=inbox", ErrInvalidFilter},
+		{"tags=a,b", ErrInvalidFilter},
+		{"tagMatch=some", ErrInvalidFilter},
+		{"limit=0", ErrInvalidFilter},
+		{"limit=501", ErrInvalidFilter},
+		{"cursor=%21%21", ErrInvalidCursor},
+		{"cursor=" + cursor, ErrInvalidCursor},
+		{"cursor=" + cursor + "&sort=name&order=desc", ErrInvalidCursor},
+	}
+
+	for _, test := range tests {
+		values, err := url.ParseQuery(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = ParseFilter(values)
+		if !errors.Is(err, test.want) {
+			t.Errorf("ParseFilter(%q) returned %v, want %v", test.query, err, test.want)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []Cursor{
+		{Sort: SortDueDate, DueDate: time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC), Id: uuid.New()},
+		{Sort: SortPriority, Descending: true, Priority: 3, Id: uuid.New()},
+		{Sort: SortName, Name: "Call the bank, then \"pay\"", Id: uuid.New()},
+		{Sort: SortRank, Rank: "i0k", Id: uuid.New()},
+	}
+
+	for _, cursor := range tests {
+		encoded, err := cursor.Encode()
+		if err != nil {
+			t.Fatalf("Encode(%+v) returned %v", cursor, err)
+		}
+
+		decoded, err := DecodeCursor(encoded)
+		if err != nil {
+			t.Fatalf("DecodeCursor(%q) returned %v", encoded, err)
+		}
+		if !reflect.DeepEqual(*decoded, cursor) {
+			t.Errorf("DecodeCursor(Encode(%+v)) = %+v", cursor, *decoded)
+		}
+	}
+}
+
+func TestParseFilterCursor(t *testing.T) {
+	cursor := Cursor{Sort: SortName, Descending: true, Name: "b", Id: uuid.New()}
+	encoded, err := cursor.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := ParseFilter(url.Values{"sort": {"name"}, "order": {"desc"}, "cursor": {encoded}})
+	if err != nil {
+		t.Fatalf("ParseFilter returned %v", err)
+	}
+	if filter.After == nil || !reflect.DeepEqual(*filter.After, cursor) {
+		t.Errorf("ParseFilter cursor = %+v, want %+v", filter.After, cursor)
+	}
+}
@@ -3,8 +3,13 @@ package task
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"task-server/middleware"
 
 	"github.com/google/uuid"
@@ -40,7 +45,83 @@ func (h *HandlerImp) handleInvalidPriority(w http.ResponseWriter) {
 	http.Error(w, "Invalid priority", http.StatusBadRequest)
 }
 
-// HandleGet will handle all get request and send all task.
+// handleInvalidEstimate will respond each time an estimate has an unknown unit or a value out of range.
+func (h *HandlerImp) handleInvalidEstimate(w http.ResponseWriter) {
+	http.Error(w, "Invalid estimate", http.StatusBadRequest)
+}
+
+// handleInvalidFilter will respond each time the listing query parameters are invalid.
+func (h *HandlerImp) handleInvalidFilter(w http.ResponseWriter) {
+	http.Error(w, "Invalid filter", http.StatusBadRequest)
+}
+
+// handleInvalidId will respond each time the task id is not a valid uuid.
+func (h *HandlerImp) handleInvalidId(w http.ResponseWriter) {
+	http.Error(w, "Invalid id", http.StatusBadRequest)
+}
+
+// handleTaskNotFound will respond each time the task is not found or belongs to another user.
+func (h *HandlerImp) handleTaskNotFound(w http.ResponseWriter) {
+	http.Error(w, "Task not found", http.StatusNotFound)
+}
+
+// handleInvalidRecurrence will respond each time there is an invalid recurrence rule or scope.
+func (h *HandlerImp) handleInvalidRecurrence(w http.ResponseWriter) {
+	http.Error(w, "Invalid recurrence", http.StatusBadRequest)
+}
+
+// handleInvalidParent will respond each time the parent task is not found or would create a cycle.
+func (h *HandlerImp) handleInvalidParent(w http.ResponseWriter) {
+	http.Error(w, "Invalid parent", http.StatusBadRequest)
+}
+
+// handleMaxDepthExceeded will respond each time a task would be nested too deep.
+func (h *HandlerImp) handleMaxDepthExceeded(w http.ResponseWriter) {
+	http.Error(w, "Max depth exceeded", http.StatusBadRequest)
+}
+
+// handleVersionMismatch will respond each time the If-Match header does not match the task version.
+func (h *HandlerImp) handleVersionMismatch(w http.ResponseWriter) {
+	http.Error(w, "Precondition failed", http.StatusPreconditionFailed)
+}
+
+// handleForbidden will respond each time the role of a user on a shared task does not allow an action.
+func (h *HandlerImp) handleForbidden(w http.ResponseWriter) {
+	http.Error(w, "Forbidden", http.StatusForbidden)
+}
+
+// parseIfMatch will return the task version required by the If-Match header.
+// It returns nil if the header is missing or matches any version.
+func parseIfMatch(r *http.Request) (*int64, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || len(header) < 2 {
+		return nil, ErrVersionMismatch
+	}
+
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil {
+		return nil, ErrVersionMismatch
+	}
+	return &version, nil
+}
+
+// writeTask will respond with a task and its version as the ETag header.
+func (h *HandlerImp) writeTask(w http.ResponseWriter, task *Task) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, task.Version))
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(task)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-writeTask: %v", err)
+	}
+}
+
+// HandleGet will handle get requests and send a page of tasks matching the query parameters.
 func (h *HandlerImp) HandleGet(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		h.handleInvalidMethod(w)
@@ -53,23 +134,74 @@ func (h *HandlerImp) HandleGet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tasks, err := h.Service.GetTasks(&token)
+	filter, err := ParseFilter(r.URL.Query())
+	if errors.Is(err, ErrInvalidCursor) {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.handleInvalidFilter(w)
+		return
+	}
+
+	page, err := h.Service.GetTasks(&token, filter)
 	if errors.Is(err, ErrInvalidToken) {
 		h.handleInvalidToken(w)
 		return
+	} else if errors.Is(err, ErrInvalidFilter) {
+		h.handleInvalidFilter(w)
+		return
 	} else if err != nil {
 		log.Printf("Error in task-HandlerImp-HandleGet: %v", err)
 		h.handleServerError(w)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(tasks)
-
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(page)
 	if err != nil {
 		log.Printf("Error in task-HandlerImp-HandleGet: %v", err)
+	}
+}
+
+// HandleSearch will handle get requests and send the tasks matching the text in the q query parameter ordered by rank.
+// The other query parameters filter the tasks like in HandleGet, except sort, order and cursor.
+func (h *HandlerImp) HandleSearch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	filter, err := ParseFilter(r.URL.Query())
+	if err != nil {
+		h.handleInvalidFilter(w)
+		return
+	}
+
+	results, err := h.Service.SearchTasks(&token, r.URL.Query().Get("q"), filter)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrInvalidFilter) {
+		h.handleInvalidFilter(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleSearch: %v", err)
 		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(results)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleSearch: %v", err)
 	}
 }
 
@@ -93,10 +225,25 @@ func (h *HandlerImp) HandlePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newTask, err := h.Service.AddTask(&token, &receivedTask)
+	newTask, undoToken, err := h.Service.AddTask(&token, &receivedTask)
 	if errors.Is(err, ErrInvalidPriority) {
 		h.handleInvalidPriority(w)
 		return
+	} else if errors.Is(err, ErrInvalidEstimate) {
+		h.handleInvalidEstimate(w)
+		return
+	} else if errors.Is(err, ErrInvalidRecurrence) {
+		h.handleInvalidRecurrence(w)
+		return
+	} else if errors.Is(err, ErrInvalidParent) {
+		h.handleInvalidParent(w)
+		return
+	} else if errors.Is(err, ErrMaxDepthExceeded) {
+		h.handleMaxDepthExceeded(w)
+		return
+	} else if errors.Is(err, ErrInvalidList) {
+		h.handleInvalidList(w)
+		return
 	} else if errors.Is(err, ErrInvalidToken) {
 		h.handleInvalidToken(w)
 		return
@@ -106,13 +253,8 @@ func (h *HandlerImp) HandlePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(newTask)
-	if err != nil {
-		log.Printf("Error in task-HandlerImp-HandlePost: %v", err)
-		h.handleServerError(w)
-	}
+	setUndoToken(w, undoToken)
+	h.writeTask(w, newTask)
 }
 
 // HandlePut will handle put requests for updating a task.
@@ -128,6 +270,12 @@ func (h *HandlerImp) HandlePut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	version, err := parseIfMatch(r)
+	if err != nil {
+		h.handleVersionMismatch(w)
+		return
+	}
+
 	var receivedTask Task
 	err = json.NewDecoder(r.Body).Decode(&receivedTask)
 	if err != nil {
@@ -135,27 +283,196 @@ func (h *HandlerImp) HandlePut(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedTask, err := h.Service.UpdateTask(&token, &receivedTask)
-	if errors.Is(err, ErrInvalidToken) {
+	updatedTask, undoToken, err := h.Service.UpdateTask(&token, &receivedTask, version)
+	if errors.Is(err, ErrInvalidPriority) {
+		h.handleInvalidPriority(w)
+		return
+	} else if errors.Is(err, ErrInvalidEstimate) {
+		h.handleInvalidEstimate(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
 		h.handleInvalidToken(w)
 		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if errors.Is(err, ErrVersionMismatch) {
+		h.handleVersionMismatch(w)
+		return
+	} else if errors.Is(err, ErrTaskBlocked) {
+		h.handleTaskBlocked(w)
+		return
 	} else if err != nil {
 		log.Printf("Error in task-HandlerImp-HandlePut: %v", err)
 		h.handleServerError(w)
 		return
 	}
 
+	setUndoToken(w, undoToken)
+	h.writeTask(w, updatedTask)
+}
+
+// HandleDelete will handle delete request for moving a task and optionally its subtasks to the trash.
+func (h *HandlerImp) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		h.handleVersionMismatch(w)
+		return
+	}
+
+	cascade := r.URL.Query().Get("cascade") == "true"
+	undoToken, err := h.Service.DeleteTask(&token, &id, version, cascade)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if errors.Is(err, ErrVersionMismatch) {
+		h.handleVersionMismatch(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleDelete: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	setUndoToken(w, undoToken)
 	w.WriteHeader(http.StatusOK)
-	w.Header().Set("Content-Type", "application/json")
-	err = json.NewEncoder(w).Encode(updatedTask)
+}
+
+// handleTaskAction will handle the post requests that change a single task and respond with it and its undo token.
+func (h *HandlerImp) handleTaskAction(w http.ResponseWriter, r *http.Request, change func(*string, *uuid.UUID) (*Task, string, error)) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
 	if err != nil {
-		log.Printf("Error in task-HandlerImp-HandlePut: %v", err)
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	task, undoToken, err := change(&token, &id)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if errors.Is(err, ErrTaskBlocked) {
+		h.handleTaskBlocked(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-handleTaskAction: %v", err)
 		h.handleServerError(w)
+		return
 	}
+
+	setUndoToken(w, undoToken)
+	h.writeTask(w, task)
 }
 
-// HandleDelete will handle delete request for deleting a task.
-func (h *HandlerImp) HandleDelete(w http.ResponseWriter, r *http.Request) {
+// HandleComplete will handle post requests for completing a task and optionally its subtasks.
+// A task with open blockers is only completed with force=true.
+func (h *HandlerImp) HandleComplete(w http.ResponseWriter, r *http.Request) {
+	cascade := r.URL.Query().Get("cascade") == "true"
+	force := r.URL.Query().Get("force") == "true"
+	h.handleTaskAction(w, r, func(token *string, id *uuid.UUID) (*Task, string, error) {
+		return h.Service.CompleteTask(token, id, cascade, force)
+	})
+}
+
+// HandleUncomplete will handle post requests for marking a task as not completed.
+func (h *HandlerImp) HandleUncomplete(w http.ResponseWriter, r *http.Request) {
+	h.handleTaskAction(w, r, h.Service.UncompleteTask)
+}
+
+// HandleGetTrash will handle get requests and send a page of tasks in the trash.
+func (h *HandlerImp) HandleGetTrash(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	filter, err := ParseFilter(r.URL.Query())
+	if errors.Is(err, ErrInvalidCursor) {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	} else if err != nil {
+		h.handleInvalidFilter(w)
+		return
+	}
+	filter.Status = StatusDeleted
+
+	page, err := h.Service.GetTasks(&token, filter)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrInvalidFilter) {
+		h.handleInvalidFilter(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetTrash: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetTrash: %v", err)
+	}
+}
+
+// HandleRestore will handle post requests for moving a task out of the trash.
+func (h *HandlerImp) HandleRestore(w http.ResponseWriter, r *http.Request) {
+	h.handleTaskAction(w, r, h.Service.RestoreTask)
+}
+
+// HandleEmptyTrash will handle delete requests for permanently deleting the tasks in the trash.
+// No undo token is sent, emptying the trash can not be undone.
+func (h *HandlerImp) HandleEmptyTrash(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodDelete {
 		h.handleInvalidMethod(w)
 		return
@@ -167,24 +484,245 @@ func (h *HandlerImp) HandleDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	idString := r.URL.Query().Get("id")
-	id, err := uuid.Parse(idString)
+	err = h.Service.EmptyTrash(&token)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleEmptyTrash: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandlePatch will handle merge patch requests for partially updating a task.
+func (h *HandlerImp) HandlePatch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	version, err := parseIfMatch(r)
+	if err != nil {
+		h.handleVersionMismatch(w)
+		return
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != MergePatchContentType {
+		http.Error(w, "Unsupported media type", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	patch, err := ParsePatch(data)
+	if err != nil {
+		http.Error(w, "Invalid patch", http.StatusBadRequest)
+		return
+	}
+
+	task, undoToken, err := h.Service.PatchTask(&token, &id, patch, version)
+	if errors.Is(err, ErrInvalidPriority) {
+		h.handleInvalidPriority(w)
+		return
+	} else if errors.Is(err, ErrInvalidEstimate) {
+		h.handleInvalidEstimate(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if errors.Is(err, ErrVersionMismatch) {
+		h.handleVersionMismatch(w)
+		return
+	} else if errors.Is(err, ErrTaskBlocked) {
+		h.handleTaskBlocked(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePatch: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	setUndoToken(w, undoToken)
+	h.writeTask(w, task)
+}
+
+// HandleRecurrence will handle post requests for changing the recurrence rule of a task.
+func (h *HandlerImp) HandleRecurrence(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	scope := RecurrenceScope(r.URL.Query().Get("scope"))
+	if scope == "" {
+		scope = ScopeFuture
+	}
+
+	var update RecurrenceUpdate
+	err = json.NewDecoder(r.Body).Decode(&update)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	task, undoToken, err := h.Service.UpdateRecurrence(&token, &id, update.Rule, scope)
+	if errors.Is(err, ErrInvalidRecurrence) {
+		h.handleInvalidRecurrence(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleRecurrence: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	setUndoToken(w, undoToken)
+	h.writeTask(w, task)
+}
+
+// HandleGetTree will handle get requests and send a task with all its subtasks.
+func (h *HandlerImp) HandleGetTree(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
 	if err != nil {
-		http.Error(w, "Invalid id", http.StatusBadRequest)
+		h.handleInvalidId(w)
 		return
 	}
 
-	err = h.Service.DeleteTask(&token, &id)
+	tree, err := h.Service.GetTaskTree(&token, &id)
 	if errors.Is(err, ErrInvalidToken) {
 		h.handleInvalidToken(w)
 		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
 	} else if err != nil {
-		log.Printf("Error in task-HandlerImp-HandleDelete: %v", err)
+		log.Printf("Error in task-HandlerImp-HandleGetTree: %v", err)
 		h.handleServerError(w)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(tree)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetTree: %v", err)
+	}
+}
+
+// HandleMove will handle post requests for moving a task under a new parent.
+// A missing parent query parameter will make the task a top level task.
+func (h *HandlerImp) HandleMove(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	var parentId uuid.NullUUID
+	if value := r.URL.Query().Get("parent"); value != "" {
+		parentId.UUID, err = uuid.Parse(value)
+		if err != nil {
+			h.handleInvalidParent(w)
+			return
+		}
+		parentId.Valid = true
+	}
+
+	task, undoToken, err := h.Service.MoveTask(&token, &id, &parentId)
+	if errors.Is(err, ErrInvalidParent) {
+		h.handleInvalidParent(w)
+		return
+	} else if errors.Is(err, ErrMaxDepthExceeded) {
+		h.handleMaxDepthExceeded(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleMove: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	setUndoToken(w, undoToken)
+	h.writeTask(w, task)
 }
 
 func NewHandlerImp(service Service) HandlerImp {
@@ -4,15 +4,141 @@ import "net/http"
 
 // Handler defines method for a task handler.
 type Handler interface {
-	// HandleGet will handle getting the task.
+	// HandleGet will handle getting a page of tasks.
 	HandleGet(w http.ResponseWriter, r *http.Request)
 
+	// HandleSearch will handle searching tasks.
+	HandleSearch(w http.ResponseWriter, r *http.Request)
+
 	// HandlePost will handle adding a task.
 	HandlePost(w http.ResponseWriter, r *http.Request)
 
 	// HandlePut will handle updating a task.
 	HandlePut(w http.ResponseWriter, r *http.Request)
 
-	// HandleDelete will handle deleting a task.
+	// HandleBulk will handle running many task operations in one transaction.
+	HandleBulk(w http.ResponseWriter, r *http.Request)
+
+	// HandleExport will handle exporting tasks as CSV.
+	HandleExport(w http.ResponseWriter, r *http.Request)
+
+	// HandleImport will handle importing tasks from CSV.
+	HandleImport(w http.ResponseWriter, r *http.Request)
+
+	// HandleExportTodoTxt will handle exporting tasks in the todo.txt format.
+	HandleExportTodoTxt(w http.ResponseWriter, r *http.Request)
+
+	// HandleImportTodoTxt will handle importing tasks from a todo.txt file.
+	HandleImportTodoTxt(w http.ResponseWriter, r *http.Request)
+
+	// HandlePostFeedToken will handle creating the calendar feed token of a user.
+	HandlePostFeedToken(w http.ResponseWriter, r *http.Request)
+
+	// HandleDeleteFeedToken will handle stopping the calendar feed of a user.
+	HandleDeleteFeedToken(w http.ResponseWriter, r *http.Request)
+
+	// HandleCalendarFeed will handle subscribing to the calendar feed of a user.
+	HandleCalendarFeed(w http.ResponseWriter, r *http.Request)
+
+	// HandleImportCalendar will handle importing tasks from an iCalendar file.
+	HandleImportCalendar(w http.ResponseWriter, r *http.Request)
+
+	// HandleDelete will handle moving a task to the trash.
 	HandleDelete(w http.ResponseWriter, r *http.Request)
+
+	// HandleComplete will handle completing a task.
+	HandleComplete(w http.ResponseWriter, r *http.Request)
+
+	// HandleUncomplete will handle marking a task as not completed.
+	HandleUncomplete(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetTrash will handle getting a page of tasks in the trash.
+	HandleGetTrash(w http.ResponseWriter, r *http.Request)
+
+	// HandleRestore will handle moving a task out of the trash.
+	HandleRestore(w http.ResponseWriter, r *http.Request)
+
+	// HandleEmptyTrash will handle permanently deleting the tasks in the trash.
+	HandleEmptyTrash(w http.ResponseWriter, r *http.Request)
+
+	// HandlePatch will handle partially updating a task.
+	HandlePatch(w http.ResponseWriter, r *http.Request)
+
+	// HandleRecurrence will handle changing the recurrence rule of a task.
+	HandleRecurrence(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetTree will handle getting a task with all its subtasks.
+	HandleGetTree(w http.ResponseWriter, r *http.Request)
+
+	// HandleMove will handle moving a task under a new parent.
+	HandleMove(w http.ResponseWriter, r *http.Request)
+
+	// HandleRank will handle moving a task before or after another task in the manual order.
+	HandleRank(w http.ResponseWriter, r *http.Request)
+
+	// HandleAddDependency will handle making a task blocked by another task.
+	HandleAddDependency(w http.ResponseWriter, r *http.Request)
+
+	// HandleRemoveDependency will handle stopping a task from being blocked by another task.
+	HandleRemoveDependency(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetBlockers will handle getting the tasks blocking a task.
+	HandleGetBlockers(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetWorkload will handle getting the estimates of the open tasks due this week.
+	HandleGetWorkload(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetHistory will handle getting the history of a task.
+	HandleGetHistory(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetActivity will handle getting the activity feed of a user.
+	HandleGetActivity(w http.ResponseWriter, r *http.Request)
+
+	// HandleUndo will handle reverting a change with its undo token.
+	HandleUndo(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetTags will handle getting all tags of a user.
+	HandleGetTags(w http.ResponseWriter, r *http.Request)
+
+	// HandlePostTag will handle adding a tag.
+	HandlePostTag(w http.ResponseWriter, r *http.Request)
+
+	// HandlePutTag will handle renaming and recoloring a tag.
+	HandlePutTag(w http.ResponseWriter, r *http.Request)
+
+	// HandleDeleteTag will handle deleting a tag.
+	HandleDeleteTag(w http.ResponseWriter, r *http.Request)
+
+	// HandleAttachTags will handle attaching tags to a task.
+	HandleAttachTags(w http.ResponseWriter, r *http.Request)
+
+	// HandleDetachTags will handle detaching tags from a task.
+	HandleDetachTags(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetLists will handle getting the lists of a user.
+	HandleGetLists(w http.ResponseWriter, r *http.Request)
+
+	// HandlePostList will handle adding a list.
+	HandlePostList(w http.ResponseWriter, r *http.Request)
+
+	// HandlePutList will handle updating a list.
+	HandlePutList(w http.ResponseWriter, r *http.Request)
+
+	// HandleDeleteList will handle deleting a list.
+	HandleDeleteList(w http.ResponseWriter, r *http.Request)
+
+	// HandleMoveToList will handle moving a task to another list.
+	HandleMoveToList(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetShares will handle getting the shares of a task or a list.
+	HandleGetShares(w http.ResponseWriter, r *http.Request)
+
+	// HandlePostShare will handle sharing a task or a list.
+	HandlePostShare(w http.ResponseWriter, r *http.Request)
+
+	// HandlePutShare will handle changing the role of a share.
+	HandlePutShare(w http.ResponseWriter, r *http.Request)
+
+	// HandleDeleteShare will handle revoking a share.
+	HandleDeleteShare(w http.ResponseWriter, r *http.Request)
 }
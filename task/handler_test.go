@@ -0,0 +1,152 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// serveTask will send a request with a user id as its access token to a handler and return the response.
+func serveTask(handle http.HandlerFunc, method string, target string, userId uuid.UUID, body any) *httptest.ResponseRecorder {
+	var content bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&content).Encode(body)
+	}
+
+	r := httptest.NewRequest(method, target, &content)
+	r.Header.Set("Authorization", "Bearer "+userId.String())
+	w := httptest.NewRecorder()
+	handle(w, r)
+	return w
+}
+
+func TestHandleTaskOfAnotherUser(t *testing.T) {
+	repository := newMemoryRepository()
+	h := &HandlerImp{Service: newFakeService(repository)}
+	task := repository.addTask(uuid.New(), "Report", nil)
+	changed := task
+	changed.Name = "Renamed"
+
+	tests := []struct {
+		name   string
+		handle http.HandlerFunc
+		method string
+		target string
+		body   any
+	}{
+		{"update", h.HandlePut, http.MethodPut, "/tasks", changed},
+		{"delete", h.HandleDelete, http.MethodDelete, "/tasks?id=" + task.Id.String(), nil},
+		{"delete with cascade", h.HandleDelete, http.MethodDelete, "/tasks?cascade=true&id=" + task.Id.String(), nil},
+		{"missing task", h.HandleDelete, http.MethodDelete, "/tasks?id=" + uuid.NewString(), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveTask(tt.handle, tt.method, tt.target, uuid.New(), tt.body)
+			if w.Code != http.StatusNotFound {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+			}
+		})
+	}
+
+	stored := repository.tasks[task.Id]
+	if stored.Name != task.Name || stored.Version != task.Version || stored.DateDeleted.Valid {
+		t.Errorf("the task of another user was changed to %+v", stored.Task)
+	}
+}
+
+func TestHandleTaskOfOwner(t *testing.T) {
+	repository := newMemoryRepository()
+	h := &HandlerImp{Service: newFakeService(repository)}
+	userId := uuid.New()
+	task := repository.addTask(userId, "Report", nil)
+	changed := task
+	changed.Name = "Renamed"
+
+	w := serveTask(h.HandlePut, http.MethodPut, "/tasks", userId, changed)
+	if w.Code != http.StatusOK || repository.tasks[task.Id].Name != changed.Name {
+		t.Errorf("update responded %d and stored %q", w.Code, repository.tasks[task.Id].Name)
+	}
+
+	w = serveTask(h.HandleDelete, http.MethodDelete, "/tasks?id="+task.Id.String(), userId, nil)
+	if w.Code != http.StatusOK || !repository.tasks[task.Id].DateDeleted.Valid {
+		t.Errorf("delete responded %d and left the task out of the trash", w.Code)
+	}
+}
+
+func TestHandleIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		delete  bool
+		want    int
+	}{
+		{"update with current version", `"1"`, false, http.StatusOK},
+		{"update with stale version", `"2"`, false, http.StatusPreconditionFailed},
+		{"update with any version", "*", false, http.StatusOK},
+		{"update without version", "", false, http.StatusOK},
+		{"update with unquoted version", "1", false, http.StatusPreconditionFailed},
+		{"update with malformed version", `"one"`, false, http.StatusPreconditionFailed},
+		{"delete with current version", `"1"`, true, http.StatusOK},
+		{"delete with stale version", `"0"`, true, http.StatusPreconditionFailed},
+		{"delete with malformed version", `W/"1"`, true, http.StatusPreconditionFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := newMemoryRepository()
+			h := &HandlerImp{Service: newFakeService(repository)}
+			userId := uuid.New()
+			task := repository.addTask(userId, "Report", nil)
+			changed := task
+			changed.Name = "Renamed"
+
+			var content bytes.Buffer
+			r := httptest.NewRequest(http.MethodDelete, "/tasks?id="+task.Id.String(), &content)
+			handle := h.HandleDelete
+			if !tt.delete {
+				json.NewEncoder(&content).Encode(changed)
+				r = httptest.NewRequest(http.MethodPut, "/tasks", &content)
+				handle = h.HandlePut
+			}
+			r.Header.Set("Authorization", "Bearer "+userId.String())
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			handle(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+			stored := repository.tasks[task.Id]
+			if tt.want != http.StatusOK && stored.Version != task.Version {
+				t.Errorf("a failed precondition changed the task to version %d", stored.Version)
+			}
+			if !tt.delete && tt.want == http.StatusOK && w.Header().Get("ETag") != `"2"` {
+				t.Errorf("ETag = %s, want the version of the updated task", w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestHandleETag(t *testing.T) {
+	repository := newMemoryRepository()
+	h := &HandlerImp{Service: newFakeService(repository)}
+	userId := uuid.New()
+	task := repository.addTask(userId, "Report", nil)
+
+	w := serveTask(h.HandleComplete, http.MethodPost, "/tasks/complete?id="+task.Id.String(), userId, nil)
+	var completed Task
+	err := json.NewDecoder(w.Body).Decode(&completed)
+	if err != nil {
+		t.Fatalf("decoding the completed task returned %v", err)
+	}
+	if w.Header().Get("ETag") != `"2"` || completed.Version != 2 {
+		t.Errorf("ETag = %s for version %d, want both to be the version after the change", w.Header().Get("ETag"), completed.Version)
+	}
+}
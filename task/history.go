@@ -0,0 +1,191 @@
+package task
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Action is the kind of change a history entry records.
+type Action string
+
+const (
+	ActionCreate     Action = "create"
+	ActionUpdate     Action = "update"
+	ActionComplete   Action = "complete"
+	ActionUncomplete Action = "uncomplete"
+	ActionDelete     Action = "delete"
+	ActionRestore    Action = "restore"
+)
+
+// Change is the old and new value of a field of a task, a missing value is null.
+type Change struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// HistoryEntry records who changed a task, when and how.
+type HistoryEntry struct {
+	Id          uuid.UUID `json:"id"`
+	TaskId      uuid.UUID `json:"taskId"`
+	ActorId     uuid.UUID `json:"actorId"`
+	ActorEmail  string    `json:"actorEmail"`
+	Action      Action    `json:"action"`
+	Changes     []Change  `json:"changes"`
+	DateCreated time.Time `json:"dateCreated"`
+}
+
+// HistoryPage is a single page of history entries, the newest first.
+type HistoryPage struct {
+	Entries []HistoryEntry `json:"entries"`
+	Next    string         `json:"next,omitempty"`
+}
+
+// HistoryCursor is the position of the last entry of a history page.
+type HistoryCursor struct {
+	DateCreated time.Time `json:"dc"`
+	Id          uuid.UUID `json:"id"`
+}
+
+// Encode will encode the cursor into an opaque string.
+func (c *HistoryCursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeHistoryCursor will decode a cursor created by HistoryCursor.Encode.
+func DecodeHistoryCursor(s string) (*HistoryCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor HistoryCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
+
+// historyFields will return the recorded fields of a task, every field is null for a missing task.
+func historyFields(task *Task) []Change {
+	fields := []Change{
+		{Field: "name"}, {Field: "description"}, {Field: "priority"}, {Field: "dueDate"},
+		{Field: "dateCompleted"}, {Field: "dateDeleted"}, {Field: "recurrence"}, {Field: "parentId"}, {Field: "listId"},
+		{Field: "estimate"}, {Field: "rank"},
+	}
+	if task == nil {
+		return fields
+	}
+
+	fields[0].New = task.Name
+	fields[1].New = task.Description
+	fields[2].New = task.Priority
+	fields[3].New = task.DueDate.UTC()
+	if task.DateCompleted.Valid {
+		fields[4].New = task.DateCompleted.Time.UTC()
+	}
+	if task.DateDeleted.Valid {
+		fields[5].New = task.DateDeleted.Time.UTC()
+	}
+	if task.Recurrence != nil {
+		fields[6].New = task.Recurrence.Rule
+	}
+	if task.ParentId.Valid {
+		fields[7].New = task.ParentId.UUID
+	}
+	fields[8].New = task.ListId
+	if task.Estimate != nil {
+		fields[9].New = *task.Estimate
+	}
+	fields[10].New = task.Rank
+	return fields
+}
+
+// diffTasks will return the fields that differ between the old and new version of a task.
+func diffTasks(oldTask *Task, newTask *Task) []Change {
+	oldFields := historyFields(oldTask)
+	newFields := historyFields(newTask)
+
+	changes := make([]Change, 0)
+	for i := range newFields {
+		if !sameValue(oldFields[i].New, newFields[i].New) {
+			changes = append(changes, Change{Field: newFields[i].Field, Old: oldFields[i].New, New: newFields[i].New})
+		}
+	}
+	return changes
+}
+
+// sameValue will compare the values of a field, times are equal if they are the same instant.
+func sameValue(a any, b any) bool {
+	aTime, aIsTime := a.(time.Time)
+	bTime, bIsTime := b.(time.Time)
+	if aIsTime && bIsTime {
+		return aTime.Equal(bTime)
+	}
+	return a == b
+}
+
+// historyAction will find the action that changed a task from its old to its new version.
+func historyAction(oldTask *Task, newTask *Task) Action {
+	switch {
+	case oldTask == nil:
+		return ActionCreate
+	case newTask == nil:
+		return ActionDelete
+	case !oldTask.DateDeleted.Valid && newTask.DateDeleted.Valid:
+		return ActionDelete
+	case oldTask.DateDeleted.Valid && !newTask.DateDeleted.Valid:
+		return ActionRestore
+	case !oldTask.DateCompleted.Valid && newTask.DateCompleted.Valid:
+		return ActionComplete
+	case oldTask.DateCompleted.Valid && !newTask.DateCompleted.Valid:
+		return ActionUncomplete
+	default:
+		return ActionUpdate
+	}
+}
+
+// historyEntries will create an entry for every task that changed between two snapshots.
+// A task missing from the second snapshot was permanently deleted.
+func historyEntries(actorId *uuid.UUID, before []Task, after []Task, date time.Time) []HistoryEntry {
+	old := make(map[uuid.UUID]*Task, len(before))
+	for i := range before {
+		old[before[i].Id] = &before[i]
+	}
+
+	entries := make([]HistoryEntry, 0)
+	addEntry := func(taskId uuid.UUID, oldTask *Task, newTask *Task) {
+		changes := diffTasks(oldTask, newTask)
+		if len(changes) == 0 {
+			return
+		}
+
+		entries = append(entries, HistoryEntry{
+			Id:          uuid.New(),
+			TaskId:      taskId,
+			ActorId:     *actorId,
+			Action:      historyAction(oldTask, newTask),
+			Changes:     changes,
+			DateCreated: date,
+		})
+	}
+
+	for i := range after {
+		addEntry(after[i].Id, old[after[i].Id], &after[i])
+		delete(old, after[i].Id)
+	}
+	for i := range before {
+		if _, removed := old[before[i].Id]; removed {
+			addEntry(before[i].Id, &before[i], nil)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,117 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// parseHistoryPage will read the limit and cursor query parameters of a history request.
+func parseHistoryPage(r *http.Request) (*HistoryCursor, int, error) {
+	limit := DefaultLimit
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			return nil, 0, ErrInvalidFilter
+		}
+	}
+
+	var after *HistoryCursor
+	if value := r.URL.Query().Get("cursor"); value != "" {
+		var err error
+		after, err = DecodeHistoryCursor(value)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return after, limit, nil
+}
+
+// writeHistoryPage will respond with a page of history entries or the error of getting it.
+func (h *HandlerImp) writeHistoryPage(w http.ResponseWriter, page *HistoryPage, err error, source string) {
+	if errors.Is(err, ErrInvalidCursor) {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		return
+	} else if errors.Is(err, ErrInvalidFilter) {
+		h.handleInvalidFilter(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-%s: %v", source, err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(page)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-%s: %v", source, err)
+	}
+}
+
+// HandleGetHistory will handle get requests for the history of a task, the newest entries first.
+func (h *HandlerImp) HandleGetHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	taskId, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	after, limit, err := parseHistoryPage(r)
+	if err != nil {
+		h.writeHistoryPage(w, nil, err, "HandleGetHistory")
+		return
+	}
+
+	page, err := h.Service.GetTaskHistory(&token, &taskId, after, limit)
+	h.writeHistoryPage(w, page, err, "HandleGetHistory")
+}
+
+// HandleGetActivity will handle get requests for the activity feed of a user, the newest entries first.
+func (h *HandlerImp) HandleGetActivity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	after, limit, err := parseHistoryPage(r)
+	if err != nil {
+		h.writeHistoryPage(w, nil, err, "HandleGetActivity")
+		return
+	}
+
+	page, err := h.Service.GetActivity(&token, after, limit)
+	h.writeHistoryPage(w, page, err, "HandleGetActivity")
+}
@@ -0,0 +1,133 @@
+package task
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// historyColumns are the columns selected for every history entry, h is the history table and u the actor.
+const historyColumns = "h.id, h.task_id, h.actor_id, u.email, h.action, h.changes, h.date_created"
+
+// scanHistoryEntry will scan a row selected with historyColumns.
+func scanHistoryEntry(row rowScanner) (*HistoryEntry, error) {
+	var entry HistoryEntry
+	var changes []byte
+	err := row.Scan(&entry.Id, &entry.TaskId, &entry.ActorId, &entry.ActorEmail, &entry.Action, &changes, &entry.DateCreated)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(changes, &entry.Changes)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// GetTaskFamily will get a task owned by a user, all its descendants and the other occurrences of its series,
+// including the tasks in the trash. It is used to find the tasks a change touched.
+func (r *PostgresRepository) GetTaskFamily(taskId *uuid.UUID, userId *uuid.UUID) ([]Task, error) {
+	query := "WITH RECURSIVE family AS (" +
+		"SELECT id FROM tasks WHERE id = $1 AND user_id = $2 " +
+		"UNION SELECT t.id FROM tasks t JOIN family f ON t.parent_id = f.id) " +
+		"SELECT " + selectTaskColumns("tasks") + " FROM tasks WHERE user_id = $2 AND (id IN (SELECT id FROM family) OR " +
+		"series_id = (SELECT series_id FROM tasks WHERE id = $1 AND user_id = $2))"
+	log.Printf("Executing query in task-PostgresRepository-GetTaskFamily: %s | Parameters %s, %s", query, taskId, userId)
+
+	rows, err := r.database.Query(query, *taskId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetTaskFamily: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]Task, 0)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetTaskFamily: %v", err)
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetTaskFamily: %v", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// AddHistory will add history entries.
+func (r *PostgresRepository) AddHistory(entries []HistoryEntry) error {
+	query := "INSERT INTO task_history(id, task_id, actor_id, action, changes, date_created) VALUES ($1, $2, $3, $4, $5, $6)"
+	for _, entry := range entries {
+		changes, err := json.Marshal(entry.Changes)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-AddHistory: %v", err)
+			return err
+		}
+
+		log.Printf("Executing query in task-PostgresRepository-AddHistory: %s | Parameters %s, %s, %s, %s", query, entry.Id, entry.TaskId, entry.ActorId, entry.Action)
+		_, err = r.database.Exec(query, entry.Id, entry.TaskId, entry.ActorId, entry.Action, changes, entry.DateCreated)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-AddHistory: %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// getHistory will get the history entries matching a condition, the newest first, starting after a cursor.
+// One entry more than the limit is returned so the caller knows if there is a next page.
+func (r *PostgresRepository) getHistory(source string, condition string, args []any, after *HistoryCursor, limit int) ([]HistoryEntry, error) {
+	if after != nil {
+		args = append(args, after.DateCreated, after.Id)
+		condition += fmt.Sprintf(" AND (h.date_created, h.id) < ($%d, $%d)", len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf("SELECT %s FROM task_history h JOIN users u ON u.id = h.actor_id WHERE %s ORDER BY h.date_created DESC, h.id DESC LIMIT $%d",
+		historyColumns, condition, len(args))
+	log.Printf("Executing query in task-PostgresRepository-%s: %s | Parameters %v", source, query, args)
+
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-%s: %v", source, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]HistoryEntry, 0, limit+1)
+	for rows.Next() {
+		entry, err := scanHistoryEntry(rows)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-%s: %v", source, err)
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-%s: %v", source, err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetTaskHistory will get the history of a task, the newest entries first.
+func (r *PostgresRepository) GetTaskHistory(taskId *uuid.UUID, after *HistoryCursor, limit int) ([]HistoryEntry, error) {
+	return r.getHistory("GetTaskHistory", "h.task_id = $1", []any{*taskId}, after, limit)
+}
+
+// GetActivity will get the history of the tasks a user owns or that are shared with them and the changes made by the user,
+// the newest entries first.
+func (r *PostgresRepository) GetActivity(userId *uuid.UUID, after *HistoryCursor, limit int) ([]HistoryEntry, error) {
+	condition := "(h.actor_id = $1 OR h.task_id IN (SELECT id FROM tasks WHERE user_id = $1 OR " + sharedTasksCondition + "))"
+	return r.getHistory("GetActivity", condition, []any{*userId}, after, limit)
+}
@@ -0,0 +1,140 @@
+package task
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// recordChanges will run a change of a task in one transaction with the history of every task it touched.
+// The task, its descendants and the occurrences of its series are compared before and after the change,
+// so cascades, reparented subtasks and new occurrences are recorded as well.
+// It returns the token that reverts the change within the undo window, the token is empty if nothing changed.
+func (s *ServiceImp) recordChanges(actorId *uuid.UUID, ownerId *uuid.UUID, taskId *uuid.UUID, change func(*ServiceImp) error) (string, error) {
+	var undoToken string
+	err := s.Repository.Transaction(func(tx Repository) error {
+		service := *s
+		service.Repository = tx
+
+		before, err := tx.GetTaskFamily(taskId, ownerId)
+		if err != nil {
+			return err
+		}
+
+		err = change(&service)
+		if err != nil {
+			return err
+		}
+
+		after, err := tx.GetTaskFamily(taskId, ownerId)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		entries := historyEntries(actorId, before, after, now)
+		if len(entries) == 0 {
+			return nil
+		}
+
+		err = tx.AddHistory(entries)
+		if err != nil || s.UndoWindow <= 0 {
+			return err
+		}
+
+		undo := &Undo{
+			Id:          uuid.New(),
+			UserId:      *actorId,
+			OwnerId:     *ownerId,
+			TaskId:      *taskId,
+			Before:      before,
+			After:       after,
+			DateExpires: now.Add(s.UndoWindow),
+		}
+		err = tx.AddUndo(undo)
+		if err != nil {
+			return err
+		}
+		undoToken = undo.Id.String()
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return undoToken, nil
+}
+
+// newHistoryPage will create a page from the entries returned by the repository for a limit.
+func newHistoryPage(entries []HistoryEntry, limit int) (*HistoryPage, error) {
+	page := &HistoryPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		last := page.Entries[limit-1]
+
+		var err error
+		page.Next, err = (&HistoryCursor{DateCreated: last.DateCreated, Id: last.Id}).Encode()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// GetTaskHistory will return a page of the history of a task, every user the task is shared with can read it.
+func (s *ServiceImp) GetTaskHistory(tokenString *string, taskId *uuid.UUID, after *HistoryCursor, limit int) (*HistoryPage, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTaskHistory: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if limit < 1 || limit > MaxLimit {
+		return nil, ErrInvalidFilter
+	}
+
+	_, err = s.authorize(id, taskId, RoleViewer)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTaskHistory: %v", err)
+		return nil, err
+	}
+
+	entries, err := s.Repository.GetTaskHistory(taskId, after, limit)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTaskHistory: %v", err)
+		return nil, err
+	}
+
+	page, err := newHistoryPage(entries, limit)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTaskHistory: %v", err)
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetActivity will return a page of the history of the tasks a user owns or can see and of the changes made by the user.
+func (s *ServiceImp) GetActivity(tokenString *string, after *HistoryCursor, limit int) (*HistoryPage, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetActivity: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if limit < 1 || limit > MaxLimit {
+		return nil, ErrInvalidFilter
+	}
+
+	entries, err := s.Repository.GetActivity(id, after, limit)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetActivity: %v", err)
+		return nil, err
+	}
+
+	page, err := newHistoryPage(entries, limit)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetActivity: %v", err)
+		return nil, err
+	}
+	return page, nil
+}
@@ -0,0 +1,142 @@
+package task
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fields will return the fields of changes in their order.
+func fields(changes []Change) []string {
+	names := make([]string, 0, len(changes))
+	for _, change := range changes {
+		names = append(names, change.Field)
+	}
+	return names
+}
+
+func TestDiffTasks(t *testing.T) {
+	due := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	base := Task{Id: uuid.New(), Name: "Report", Priority: 1, DueDate: due, ListId: uuid.New()}
+
+	tests := []struct {
+		name   string
+		change func(task *Task)
+		want   []string
+	}{
+		{"nothing", func(task *Task) {}, []string{}},
+		{"name and priority", func(task *Task) { task.Name, task.Priority = "Final report", 2 }, []string{"name", "priority"}},
+		{"same instant in another zone", func(task *Task) { task.DueDate = due.In(time.FixedZone("CET", 3600)) }, []string{}},
+		{"due date", func(task *Task) { task.DueDate = due.Add(time.Hour) }, []string{"dueDate"}},
+		{"completed", func(task *Task) { task.DateCompleted = NullTime{sql.NullTime{Time: due, Valid: true}} }, []string{"dateCompleted"}},
+		{"estimate", func(task *Task) { task.Estimate = &Estimate{Value: 3, Unit: EstimatePoints} }, []string{"estimate"}},
+		{"list and parent", func(task *Task) {
+			task.ListId = uuid.New()
+			task.ParentId = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+		}, []string{"parentId", "listId"}},
+		{"version is not recorded", func(task *Task) { task.Version = 7 }, []string{}},
+		{"rank", func(task *Task) { task.Rank = "x" }, []string{"rank"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changed := base
+			test.change(&changed)
+
+			got := fields(diffTasks(&base, &changed))
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("diffTasks changed %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiffTasksValues(t *testing.T) {
+	task := Task{Name: "Report", Estimate: &Estimate{Value: 30, Unit: EstimateMinutes}}
+	changes := diffTasks(&task, &Task{Name: "Final report"})
+
+	want := map[string]Change{
+		"name":     {Field: "name", Old: "Report", New: "Final report"},
+		"estimate": {Field: "estimate", Old: Estimate{Value: 30, Unit: EstimateMinutes}, New: nil},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("diffTasks changed %v, want name and estimate", fields(changes))
+	}
+	for _, change := range changes {
+		if !reflect.DeepEqual(change, want[change.Field]) {
+			t.Errorf("change of %s = %+v, want %+v", change.Field, change, want[change.Field])
+		}
+	}
+}
+
+func TestHistoryEntries(t *testing.T) {
+	actorId := uuid.New()
+	date := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	deleted := NullTime{sql.NullTime{Time: date, Valid: true}}
+	completed := NullTime{sql.NullTime{Time: date, Valid: true}}
+
+	task := Task{Id: uuid.New(), Name: "Report", ListId: uuid.New()}
+	with := func(change func(task *Task)) Task {
+		changed := task
+		change(&changed)
+		return changed
+	}
+
+	tests := []struct {
+		name   string
+		before []Task
+		after  []Task
+		want   []Action
+	}{
+		{"created", nil, []Task{task}, []Action{ActionCreate}},
+		{"removed", []Task{task}, nil, []Action{ActionDelete}},
+		{"unchanged", []Task{task}, []Task{task}, []Action{}},
+		{"updated", []Task{task}, []Task{with(func(t *Task) { t.Name = "Final report" })}, []Action{ActionUpdate}},
+		{"completed", []Task{task}, []Task{with(func(t *Task) { t.DateCompleted = completed })}, []Action{ActionComplete}},
+		{"uncompleted", []Task{with(func(t *Task) { t.DateCompleted = completed })}, []Task{task}, []Action{ActionUncomplete}},
+		{"moved to the trash", []Task{task}, []Task{with(func(t *Task) { t.DateDeleted = deleted })}, []Action{ActionDelete}},
+		{"restored", []Task{with(func(t *Task) { t.DateDeleted = deleted })}, []Task{task}, []Action{ActionRestore}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			entries := historyEntries(&actorId, test.before, test.after, date)
+
+			actions := make([]Action, 0, len(entries))
+			for _, entry := range entries {
+				actions = append(actions, entry.Action)
+				if entry.TaskId != task.Id || entry.ActorId != actorId || !entry.DateCreated.Equal(date) || len(entry.Changes) == 0 {
+					t.Errorf("historyEntries entry = %+v", entry)
+				}
+			}
+			if !reflect.DeepEqual(actions, test.want) {
+				t.Errorf("historyEntries actions = %v, want %v", actions, test.want)
+			}
+		})
+	}
+}
+
+func TestHistoryEntriesFamily(t *testing.T) {
+	actorId := uuid.New()
+	parent := Task{Id: uuid.New(), Name: "Move", ListId: uuid.New()}
+	child := Task{Id: uuid.New(), Name: "Pack", ParentId: uuid.NullUUID{UUID: parent.Id, Valid: true}, ListId: parent.ListId}
+	added := Task{Id: uuid.New(), Name: "Clean", ListId: parent.ListId}
+
+	movedParent, movedChild := parent, child
+	movedParent.ListId = uuid.New()
+	movedChild.ListId = movedParent.ListId
+
+	entries := historyEntries(&actorId, []Task{parent, child}, []Task{movedParent, movedChild, added}, time.Now())
+
+	got := make(map[uuid.UUID]Action)
+	for _, entry := range entries {
+		got[entry.TaskId] = entry.Action
+	}
+	want := map[uuid.UUID]Action{parent.Id: ActionUpdate, child.Id: ActionUpdate, added.Id: ActionCreate}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("historyEntries actions = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package task
+
+import "github.com/google/uuid"
+
+// InboxName is the name of the list created for every user, tasks without a list are added to it.
+const InboxName = "Inbox"
+
+// List is a project owned by a user that groups tasks.
+type List struct {
+	Id        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	Archived  bool      `json:"archived"`
+	SortOrder int64     `json:"sortOrder"`
+	Inbox     bool      `json:"inbox"`
+}
+
+// NewList is a list that will be added.
+type NewList struct {
+	Name      string `json:"name"`
+	Color     string `json:"color"`
+	SortOrder int64  `json:"sortOrder"`
+}
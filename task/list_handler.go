@@ -0,0 +1,227 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// handleInvalidList will respond each time a list has an empty name or an invalid color,
+// a task is moved to an unknown list or the inbox is archived or deleted.
+func (h *HandlerImp) handleInvalidList(w http.ResponseWriter) {
+	http.Error(w, "Invalid list", http.StatusBadRequest)
+}
+
+// handleListNotFound will respond each time a list is not found or belongs to another user.
+func (h *HandlerImp) handleListNotFound(w http.ResponseWriter) {
+	http.Error(w, "List not found", http.StatusNotFound)
+}
+
+// writeList will respond with a list.
+func (h *HandlerImp) writeList(w http.ResponseWriter, list *List) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(list)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-writeList: %v", err)
+	}
+}
+
+// HandleGetLists will handle get requests and send the lists of a user.
+// Archived lists are sent only if the archived query parameter is true.
+func (h *HandlerImp) HandleGetLists(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	archived := r.URL.Query().Get("archived") == "true"
+	lists, err := h.Service.GetLists(&token, archived)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetLists: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(lists)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetLists: %v", err)
+	}
+}
+
+// HandlePostList will handle post requests for adding a list.
+func (h *HandlerImp) HandlePostList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedList NewList
+	err = json.NewDecoder(r.Body).Decode(&receivedList)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	list, err := h.Service.AddList(&token, &receivedList)
+	if errors.Is(err, ErrInvalidList) {
+		h.handleInvalidList(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePostList: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeList(w, list)
+}
+
+// HandlePutList will handle put requests for updating a list.
+func (h *HandlerImp) HandlePutList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedList List
+	err = json.NewDecoder(r.Body).Decode(&receivedList)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	list, err := h.Service.UpdateList(&token, &receivedList)
+	if errors.Is(err, ErrInvalidList) {
+		h.handleInvalidList(w)
+		return
+	} else if errors.Is(err, ErrListNotFound) {
+		h.handleListNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePutList: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeList(w, list)
+}
+
+// HandleDeleteList will handle delete requests for deleting a list.
+func (h *HandlerImp) HandleDeleteList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	err = h.Service.DeleteList(&token, &id)
+	if errors.Is(err, ErrInvalidList) {
+		h.handleInvalidList(w)
+		return
+	} else if errors.Is(err, ErrListNotFound) {
+		h.handleListNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleDeleteList: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleMoveToList will handle post requests for moving a task with its subtasks to another list.
+func (h *HandlerImp) HandleMoveToList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	listId, err := uuid.Parse(r.URL.Query().Get("list"))
+	if err != nil {
+		h.handleInvalidList(w)
+		return
+	}
+
+	task, undoToken, err := h.Service.MoveToList(&token, &id, &listId)
+	if errors.Is(err, ErrInvalidList) {
+		h.handleInvalidList(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleMoveToList: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	setUndoToken(w, undoToken)
+	h.writeTask(w, task)
+}
@@ -0,0 +1,187 @@
+package task
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// listColumns are the columns selected for every list.
+const listColumns = "id, name, color, archived, sort_order, inbox"
+
+// scanList will scan a row selected with listColumns.
+func scanList(row rowScanner) (*List, error) {
+	var list List
+	err := row.Scan(&list.Id, &list.Name, &list.Color, &list.Archived, &list.SortOrder, &list.Inbox)
+	if err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetLists will get the lists of a user and the lists shared with them ordered by their sort order.
+// Archived lists are included only if asked for.
+func (r *PostgresRepository) GetLists(userId *uuid.UUID, archived bool) ([]List, error) {
+	query := "SELECT " + listColumns + " FROM lists WHERE (user_id = $1 OR id IN (SELECT list_id FROM shares WHERE user_id = $1)) AND (archived = false OR $2) ORDER BY sort_order, name, id"
+	log.Printf("Executing query in task-PostgresRepository-GetLists: %s | Parameters %s, %t", query, userId, archived)
+
+	rows, err := r.database.Query(query, *userId, archived)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetLists: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := make([]List, 0)
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetLists: %v", err)
+			return nil, err
+		}
+		lists = append(lists, *list)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetLists: %v", err)
+		return nil, err
+	}
+	return lists, nil
+}
+
+// GetList will get a list owned by a user.
+func (r *PostgresRepository) GetList(listId *uuid.UUID, userId *uuid.UUID) (*List, error) {
+	query := "SELECT " + listColumns + " FROM lists WHERE id = $1 AND user_id = $2"
+	log.Printf("Executing query in task-PostgresRepository-GetList: %s | Parameters %s, %s", query, listId, userId)
+
+	list, err := scanList(r.database.QueryRow(query, *listId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrListNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetList: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetInbox will get the inbox list of a user.
+func (r *PostgresRepository) GetInbox(userId *uuid.UUID) (*List, error) {
+	query := "SELECT " + listColumns + " FROM lists WHERE user_id = $1 AND inbox = true"
+	log.Printf("Executing query in task-PostgresRepository-GetInbox: %s | Parameters %s", query, userId)
+
+	list, err := scanList(r.database.QueryRow(query, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrListNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetInbox: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// AddList will add a new list to a user.
+func (r *PostgresRepository) AddList(list *List, userId *uuid.UUID) error {
+	query := "INSERT INTO lists(id, name, color, archived, sort_order, inbox, user_id) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	log.Printf("Executing query in task-PostgresRepository-AddList: %s | Parameters %s, %s, %s, %t, %d, %t, %s", query, list.Id, list.Name, list.Color, list.Archived, list.SortOrder, list.Inbox, userId)
+
+	_, err := r.database.Exec(query, list.Id, list.Name, list.Color, list.Archived, list.SortOrder, list.Inbox, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddList: %v", err)
+	}
+	return err
+}
+
+// AddInbox will add the inbox list of a user unless they already have one.
+// The unique index on the inbox of a user makes concurrent first requests add a single inbox.
+func (r *PostgresRepository) AddInbox(list *List, userId *uuid.UUID) error {
+	query := "INSERT INTO lists(id, name, color, archived, sort_order, inbox, user_id) VALUES ($1, $2, $3, false, 0, true, $4) ON CONFLICT (user_id) WHERE inbox DO NOTHING"
+	log.Printf("Executing query in task-PostgresRepository-AddInbox: %s | Parameters %s, %s, %s, %s", query, list.Id, list.Name, list.Color, userId)
+
+	_, err := r.database.Exec(query, list.Id, list.Name, list.Color, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddInbox: %v", err)
+	}
+	return err
+}
+
+// UpdateList will update the name, color, archive flag and sort order of a list owned by a user.
+func (r *PostgresRepository) UpdateList(list *List, userId *uuid.UUID) (*List, error) {
+	query := "UPDATE lists SET name = $1, color = $2, archived = $3, sort_order = $4 WHERE id = $5 AND user_id = $6 RETURNING " + listColumns
+	log.Printf("Executing query in task-PostgresRepository-UpdateList: %s | Parameters %s, %s, %t, %d, %s, %s", query, list.Name, list.Color, list.Archived, list.SortOrder, list.Id, userId)
+
+	updatedList, err := scanList(r.database.QueryRow(query, list.Name, list.Color, list.Archived, list.SortOrder, list.Id, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrListNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-UpdateList: %v", err)
+		return nil, err
+	}
+	return updatedList, nil
+}
+
+// GetListTasks will get the tasks of a list owned by a user including the tasks in the trash.
+// It is used to find the tasks a change of the list touched.
+func (r *PostgresRepository) GetListTasks(listId *uuid.UUID, userId *uuid.UUID) ([]Task, error) {
+	query := "SELECT " + selectTaskColumns("tasks") + " FROM tasks WHERE list_id = $1 AND user_id = $2"
+	log.Printf("Executing query in task-PostgresRepository-GetListTasks: %s | Parameters %s, %s", query, listId, userId)
+
+	rows, err := r.database.Query(query, *listId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetListTasks: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]Task, 0)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetListTasks: %v", err)
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetListTasks: %v", err)
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// DeleteList will move the tasks of a list owned by a user to another list and delete it.
+func (r *PostgresRepository) DeleteList(listId *uuid.UUID, userId *uuid.UUID, targetId *uuid.UUID) error {
+	query := "UPDATE tasks SET list_id = $1, version = version + 1 WHERE list_id = $2 AND user_id = $3"
+	log.Printf("Executing query in task-PostgresRepository-DeleteList: %s | Parameters %s, %s, %s", query, targetId, listId, userId)
+
+	_, err := r.database.Exec(query, *targetId, *listId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteList: %v", err)
+		return err
+	}
+
+	query = "DELETE FROM lists WHERE id = $1 AND user_id = $2"
+	log.Printf("Executing query in task-PostgresRepository-DeleteList: %s | Parameters %s, %s", query, listId, userId)
+
+	_, err = r.database.Exec(query, *listId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteList: %v", err)
+	}
+	return err
+}
+
+// MoveToList will move a task owned by a user and all its descendants to a list.
+func (r *PostgresRepository) MoveToList(taskId *uuid.UUID, userId *uuid.UUID, listId *uuid.UUID) error {
+	query := descendantsQuery + "UPDATE tasks SET list_id = $3, version = version + 1 WHERE (id = $1 OR id IN (SELECT id FROM descendants)) AND user_id = $2"
+	log.Printf("Executing query in task-PostgresRepository-MoveToList: %s | Parameters %s, %s, %s", query, taskId, userId, listId)
+
+	_, err := r.database.Exec(query, *taskId, *userId, *listId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-MoveToList: %v", err)
+	}
+	return err
+}
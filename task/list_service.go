@@ -0,0 +1,232 @@
+package task
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// inboxColor is the color of the inbox list created for a user.
+const inboxColor = "#808080"
+
+// inbox will return the inbox list of a user, creating it the first time it is needed.
+func (s *ServiceImp) inbox(userId *uuid.UUID) (*List, error) {
+	list, err := s.Repository.GetInbox(userId)
+	if !errors.Is(err, ErrListNotFound) {
+		return list, err
+	}
+
+	// a concurrent request may add the inbox first, the inbox that was added is read back
+	list = &List{
+		Id:    uuid.New(),
+		Name:  InboxName,
+		Color: inboxColor,
+		Inbox: true,
+	}
+	err = s.Repository.AddInbox(list, userId)
+	if err != nil {
+		return nil, err
+	}
+	return s.Repository.GetInbox(userId)
+}
+
+// GetLists will return the lists of a user, archived lists are included only if asked for.
+func (s *ServiceImp) GetLists(tokenString *string, archived bool) ([]List, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetLists: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	_, err = s.inbox(id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetLists: %v", err)
+		return nil, err
+	}
+
+	lists, err := s.Repository.GetLists(id, archived)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetLists: %v", err)
+		return nil, err
+	}
+	return lists, nil
+}
+
+// AddList will add a new list to a user.
+func (s *ServiceImp) AddList(tokenString *string, newList *NewList) (*List, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddList: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkTag(newList.Name, newList.Color) {
+		return nil, ErrInvalidList
+	}
+
+	list := &List{
+		Id:        uuid.New(),
+		Name:      newList.Name,
+		Color:     newList.Color,
+		SortOrder: newList.SortOrder,
+	}
+	err = s.Repository.AddList(list, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddList: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// UpdateList will rename, recolor, reorder and archive a list. The inbox cannot be archived.
+func (s *ServiceImp) UpdateList(tokenString *string, list *List) (*List, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateList: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkTag(list.Name, list.Color) {
+		return nil, ErrInvalidList
+	}
+
+	current, err := s.Repository.GetList(&list.Id, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateList: %v", err)
+		return nil, err
+	}
+	if current.Inbox && list.Archived {
+		return nil, ErrInvalidList
+	}
+
+	updatedList, err := s.Repository.UpdateList(list, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateList: %v", err)
+		return nil, err
+	}
+	return updatedList, nil
+}
+
+// DeleteList will delete a list and move its tasks to the inbox, recording the move in the history of every task.
+// The inbox cannot be deleted.
+func (s *ServiceImp) DeleteList(tokenString *string, listId *uuid.UUID) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteList: %v", err)
+		return ErrInvalidToken
+	}
+
+	list, err := s.Repository.GetList(listId, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteList: %v", err)
+		return err
+	}
+	if list.Inbox {
+		return ErrInvalidList
+	}
+
+	err = s.Repository.Transaction(func(tx Repository) error {
+		service := *s
+		service.Repository = tx
+
+		inbox, err := service.inbox(id)
+		if err != nil {
+			return err
+		}
+
+		before, err := tx.GetListTasks(listId, id)
+		if err != nil {
+			return err
+		}
+
+		err = tx.DeleteList(listId, id, &inbox.Id)
+		if err != nil {
+			return err
+		}
+
+		inboxTasks, err := tx.GetListTasks(&inbox.Id, id)
+		if err != nil {
+			return err
+		}
+
+		// every moved task gets an entry like a single move, the tasks that were in the inbox already are left out
+		moved := make(map[uuid.UUID]bool, len(before))
+		for _, task := range before {
+			moved[task.Id] = true
+		}
+		after := make([]Task, 0, len(before))
+		for _, task := range inboxTasks {
+			if moved[task.Id] {
+				after = append(after, task)
+			}
+		}
+
+		entries := historyEntries(id, before, after, time.Now().UTC())
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.AddHistory(entries)
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteList: %v", err)
+		return err
+	}
+	return nil
+}
+
+// MoveToList will move a task with its subtasks to another list and return the token that moves it back.
+// A subtask moved to a list different from the one of its parent is detached from the parent.
+func (s *ServiceImp) MoveToList(tokenString *string, taskId *uuid.UUID, listId *uuid.UUID) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveToList: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleAdmin)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveToList: %v", err)
+		return nil, "", err
+	}
+
+	_, err = s.Repository.GetList(listId, ownerId)
+	if errors.Is(err, ErrListNotFound) {
+		return nil, "", ErrInvalidList
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveToList: %v", err)
+		return nil, "", err
+	}
+
+	task, err := s.Repository.GetTask(taskId, ownerId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveToList: %v", err)
+		return nil, "", err
+	}
+	if task.ListId == *listId {
+		return task, "", nil
+	}
+
+	undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+		if task.ParentId.Valid {
+			_, err := service.Repository.MoveTask(taskId, ownerId, &uuid.NullUUID{})
+			if err != nil {
+				return err
+			}
+		}
+
+		err := service.Repository.MoveToList(taskId, ownerId, listId)
+		if err != nil {
+			return err
+		}
+
+		task, err = service.Repository.GetTask(taskId, ownerId)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveToList: %v", err)
+		return nil, "", err
+	}
+	return task, undoToken, nil
+}
@@ -0,0 +1,121 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAddTaskList(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+
+	task, _, err := service.AddTask(&token, &NewTask{Name: "Report", Priority: 1})
+	if err != nil {
+		t.Fatalf("AddTask returned %v", err)
+	}
+	inbox, err := repository.GetInbox(&userId)
+	if err != nil {
+		t.Fatalf("adding a task without a list did not create the inbox: %v", err)
+	}
+	if task.ListId != inbox.Id {
+		t.Errorf("task without a list was added to %s, want the inbox %s", task.ListId, inbox.Id)
+	}
+
+	list := &List{Id: uuid.New(), Name: "Work"}
+	repository.AddList(list, &userId)
+	parent, _, err := service.AddTask(&token, &NewTask{Name: "Release", Priority: 1, ListId: uuid.NullUUID{UUID: list.Id, Valid: true}})
+	if err != nil {
+		t.Fatalf("AddTask with a list returned %v", err)
+	}
+	if parent.ListId != list.Id {
+		t.Errorf("task was added to %s, want the list %s", parent.ListId, list.Id)
+	}
+
+	subtask, _, err := service.AddTask(&token, &NewTask{Name: "Changelog", Priority: 1, ParentId: uuid.NullUUID{UUID: parent.Id, Valid: true}})
+	if err != nil {
+		t.Fatalf("AddTask of a subtask returned %v", err)
+	}
+	if subtask.ListId != list.Id {
+		t.Errorf("subtask was added to %s, want the list of its parent %s", subtask.ListId, list.Id)
+	}
+
+	otherId := uuid.New()
+	foreign := &List{Id: uuid.New(), Name: "Other"}
+	repository.AddList(foreign, &otherId)
+	_, _, err = service.AddTask(&token, &NewTask{Name: "Sneak", Priority: 1, ListId: uuid.NullUUID{UUID: foreign.Id, Valid: true}})
+	if !errors.Is(err, ErrInvalidList) {
+		t.Errorf("AddTask to the list of another user returned %v, want %v", err, ErrInvalidList)
+	}
+}
+
+func TestDeleteList(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+	kept := repository.addTask(userId, "Groceries", nil)
+	inbox, _ := repository.GetInbox(&userId)
+
+	list := &List{Id: uuid.New(), Name: "Work"}
+	repository.AddList(list, &userId)
+	moved := repository.addTask(userId, "Report", nil)
+	stored := repository.tasks[moved.Id]
+	stored.ListId = list.Id
+	repository.tasks[moved.Id] = stored
+
+	err := service.DeleteList(&token, &list.Id)
+	if err != nil {
+		t.Fatalf("DeleteList returned %v", err)
+	}
+	if _, ok := repository.lists[list.Id]; ok {
+		t.Error("the list was not deleted")
+	}
+	for _, task := range []Task{kept, moved} {
+		if repository.tasks[task.Id].ListId != inbox.Id {
+			t.Errorf("%s is in %s, want the inbox %s", task.Name, repository.tasks[task.Id].ListId, inbox.Id)
+		}
+	}
+
+	err = service.DeleteList(&token, &inbox.Id)
+	if !errors.Is(err, ErrInvalidList) {
+		t.Errorf("deleting the inbox returned %v, want %v", err, ErrInvalidList)
+	}
+	if _, ok := repository.lists[inbox.Id]; !ok {
+		t.Error("the inbox was deleted")
+	}
+}
+
+func TestDeleteListHistory(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+	repository.addTask(userId, "Groceries", nil)
+	inbox, _ := repository.GetInbox(&userId)
+
+	list := &List{Id: uuid.New(), Name: "Work"}
+	repository.AddList(list, &userId)
+	moved := repository.addTask(userId, "Report", nil)
+	stored := repository.tasks[moved.Id]
+	stored.ListId = list.Id
+	repository.tasks[moved.Id] = stored
+
+	err := service.DeleteList(&token, &list.Id)
+	if err != nil {
+		t.Fatalf("DeleteList returned %v", err)
+	}
+	if len(repository.history) != 1 || repository.history[0].TaskId != moved.Id {
+		t.Fatalf("history = %+v, want a single entry for the moved task", repository.history)
+	}
+	changes := repository.history[0].Changes
+	if !slices.ContainsFunc(changes, func(change Change) bool {
+		return change.Field == "listId" && change.Old == list.Id && change.New == inbox.Id
+	}) {
+		t.Errorf("changes = %+v, want the list to change to the inbox", changes)
+	}
+}
@@ -0,0 +1,84 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// MergePatchContentType is the media type of RFC 7396 merge patch documents.
+const MergePatchContentType = "application/merge-patch+json"
+
+// Patch holds the fields changed by a merge patch document.
+// A nil field was not present in the document and will be left unchanged.
+type Patch struct {
+	Name          *string
+	Description   *string
+	Priority      *int64
+	DueDate       *time.Time
+	DateCompleted *NullTime
+	// Estimate points to a nil estimate when the document removes it.
+	Estimate **Estimate
+}
+
+// IsEmpty will check if the patch does not change any field.
+func (p *Patch) IsEmpty() bool {
+	return p.Name == nil && p.Description == nil && p.Priority == nil && p.DueDate == nil && p.DateCompleted == nil && p.Estimate == nil
+}
+
+// isNull will check if a json value is null.
+func isNull(value json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(value), []byte("null"))
+}
+
+// ParsePatch will parse a merge patch document for a task.
+// Unknown members and null values for fields that can not be removed are rejected.
+func ParsePatch(data []byte) (*Patch, error) {
+	var members map[string]json.RawMessage
+	err := json.Unmarshal(data, &members)
+	if err != nil || members == nil {
+		return nil, ErrInvalidPatch
+	}
+
+	var patch Patch
+	for key, value := range members {
+		var target any
+		switch key {
+		case "name":
+			patch.Name = new(string)
+			target = patch.Name
+		case "description":
+			patch.Description = new(string)
+			target = patch.Description
+		case "priority":
+			patch.Priority = new(int64)
+			target = patch.Priority
+		case "dueDate":
+			patch.DueDate = new(time.Time)
+			target = patch.DueDate
+		case "dateCompleted":
+			patch.DateCompleted = new(NullTime)
+			target = patch.DateCompleted
+		case "estimate":
+			patch.Estimate = new(*Estimate)
+			target = patch.Estimate
+		default:
+			return nil, ErrInvalidPatch
+		}
+
+		if isNull(value) && key != "dateCompleted" && key != "estimate" {
+			return nil, ErrInvalidPatch
+		}
+
+		err = json.Unmarshal(value, target)
+		if err != nil {
+			return nil, ErrInvalidPatch
+		}
+	}
+
+	if patch.Name != nil && *patch.Name == "" {
+		return nil, ErrInvalidPatch
+	}
+
+	return &patch, nil
+}
@@ -0,0 +1,103 @@
+package task
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePatch(t *testing.T) {
+	completed := time.Date(2026, time.January, 5, 10, 0, 0, 0, time.UTC)
+	due := time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		document string
+		want     func(patch *Patch) bool
+	}{
+		{
+			name:     "empty document",
+			document: `{}`,
+			want:     func(patch *Patch) bool { return patch.IsEmpty() },
+		},
+		{
+			name:     "name and priority",
+			document: `{"name": "Call the bank", "priority": 3}`,
+			want: func(patch *Patch) bool {
+				return *patch.Name == "Call the bank" && *patch.Priority == 3 && patch.Description == nil && patch.DueDate == nil
+			},
+		},
+		{
+			name:     "empty description",
+			document: `{"description": ""}`,
+			want:     func(patch *Patch) bool { return patch.Description != nil && *patch.Description == "" },
+		},
+		{
+			name:     "due date",
+			document: `{"dueDate": "2026-02-01T09:00:00Z"}`,
+			want:     func(patch *Patch) bool { return patch.DueDate.Equal(due) },
+		},
+		{
+			name:     "completed",
+			document: `{"dateCompleted": "2026-01-05T10:00:00Z"}`,
+			want: func(patch *Patch) bool {
+				return patch.DateCompleted.Valid && patch.DateCompleted.Time.Equal(completed)
+			},
+		},
+		{
+			name:     "uncompleted",
+			document: `{"dateCompleted": null}`,
+			want:     func(patch *Patch) bool { return patch.DateCompleted != nil && !patch.DateCompleted.Valid },
+		},
+		{
+			name:     "estimate",
+			document: `{"estimate": {"value": 30, "unit": "minutes"}}`,
+			want: func(patch *Patch) bool {
+				return reflect.DeepEqual(*patch.Estimate, &Estimate{Value: 30, Unit: EstimateMinutes})
+			},
+		},
+		{
+			name:     "estimate removed",
+			document: `{"estimate": null}`,
+			want:     func(patch *Patch) bool { return patch.Estimate != nil && *patch.Estimate == nil && !patch.IsEmpty() },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			patch, err := ParsePatch([]byte(test.document))
+			if err != nil {
+				t.Fatalf("ParsePatch(%s) returned %v", test.document, err)
+			}
+			if !test.want(patch) {
+				t.Errorf("ParsePatch(%s) = %+v", test.document, *patch)
+			}
+		})
+	}
+}
+
+func TestParsePatchInvalid(t *testing.T) {
+	tests := []string{
+		``,
+		`null`,
+		`[]`,
+		`"name"`,
+		`{"id": "0f0e4a52-1c9d-4f5b-8e47-9a1b2c3d4e5f"}`,
+		`{"name": null}`,
+		`{"name": ""}`,
+		`{"priority": null}`,
+		`{"priority": "high"}`,
+		`{"dueDate": null}`,
+		`{"dueDate": "tomorrow"}`,
+		`{"dateCompleted": 5}`,
+		`{"estimate": 30}`,
+	}
+
+	for _, document := range tests {
+		_, err := ParsePatch([]byte(document))
+		if !errors.Is(err, ErrInvalidPatch) {
+			t.Errorf("ParsePatch(%s) returned %v, want %v", document, err, ErrInvalidPatch)
+		}
+	}
+}
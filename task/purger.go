@@ -0,0 +1,64 @@
+package task
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Purger will permanently delete the tasks that stayed in the trash longer than the retention period
+// and the undo tokens that expired. Changes of purged tasks can no longer be undone.
+type Purger struct {
+	Repository Repository
+	// Retention is how long a task is kept in the trash.
+	Retention time.Duration
+	// Interval is how often the trash is checked.
+	Interval time.Duration
+}
+
+// purge will delete the tasks with an expired retention period and the expired undo tokens.
+func (p *Purger) purge() {
+	now := time.Now().UTC()
+	undos, err := p.Repository.DeleteExpiredUndos(&now)
+	if err != nil {
+		log.Printf("Error in task-Purger-purge: %v", err)
+	} else if undos > 0 {
+		log.Printf("Deleted %d expired undo tokens", undos)
+	}
+
+	before := now.Add(-p.Retention)
+	count, err := p.Repository.PurgeTasks(&before)
+	if err != nil {
+		log.Printf("Error in task-Purger-purge: %v", err)
+		return
+	}
+
+	if count > 0 {
+		log.Printf("Purged %d tasks from the trash", count)
+	}
+}
+
+// Run will purge the trash on every interval until the context is done.
+func (p *Purger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.purge()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewPurger will create a new purger with a repository, retention period and check interval.
+func NewPurger(repository Repository, retention time.Duration, interval time.Duration) Purger {
+	return Purger{
+		Repository: repository,
+		Retention:  retention,
+		Interval:   interval,
+	}
+}
@@ -0,0 +1,97 @@
+package task
+
+import "strings"
+
+// rankDigits are the digits of a rank key in ascending order, keys are compared byte by byte.
+// A key never ends with the smallest digit, so there is always a key before it.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// Placement is where a task is moved relative to another task in the manual order.
+type Placement string
+
+const (
+	PlaceBefore Placement = "before"
+	PlaceAfter  Placement = "after"
+)
+
+// rankAfter will return a key that comes after a rank, an empty rank is before every key.
+func rankAfter(rank string) string {
+	for i := 0; i < len(rank); i++ {
+		digit := strings.IndexByte(rankDigits, rank[i])
+		if digit < len(rankDigits)-1 {
+			return rank[:i] + string(rankDigits[digit+1])
+		}
+	}
+	return rank + string(rankDigits[len(rankDigits)/2])
+}
+
+// rankBefore will return a key that comes before a rank that is not empty.
+func rankBefore(rank string) string {
+	for i := 0; i < len(rank); i++ {
+		digit := strings.IndexByte(rankDigits, rank[i])
+		if digit > 1 {
+			return rank[:i] + string(rankDigits[digit-1])
+		}
+	}
+	// the rank only has the two smallest digits and ends with the second one
+	return rank[:len(rank)-1] + string(rankDigits[0]) + string(rankDigits[len(rankDigits)/2])
+}
+
+// rankBetween will return a key between two ranks, where before must come before after.
+// An empty before is before every key and an empty after is after every key.
+func rankBetween(before string, after string) string {
+	if after == "" {
+		return rankAfter(before)
+	} else if before == "" {
+		return rankBefore(after)
+	}
+
+	// A missing digit of before is the smallest digit, so the common prefix may be longer than before.
+	n := 0
+	for n < len(after) && (n < len(before) && before[n] == after[n] || n >= len(before) && after[n] == rankDigits[0]) {
+		n++
+	}
+
+	low := 0
+	if n < len(before) {
+		low = strings.IndexByte(rankDigits, before[n])
+	}
+	high := strings.IndexByte(rankDigits, after[n])
+	if high-low > 1 {
+		return after[:n] + string(rankDigits[(low+high)/2])
+	}
+
+	// The digits are adjacent, so the key is a prefix of after or continues after the rest of before.
+	if n+1 < len(after) {
+		return after[:n+1]
+	}
+	rest := ""
+	if n+1 < len(before) {
+		rest = before[n+1:]
+	}
+	return after[:n] + string(rankDigits[low]) + rankAfter(rest)
+}
+
+// spreadRanks will return n keys in ascending order spaced evenly, all of them as short as possible.
+func spreadRanks(n int) []string {
+	base := uint64(len(rankDigits))
+	length := 1
+	space := base
+	// every gap between two keys holds at least a whole digit of keys
+	for space < uint64(n+1)*base {
+		length++
+		space *= base
+	}
+
+	ranks := make([]string, n)
+	digits := make([]byte, length)
+	for i := range ranks {
+		value := uint64(i+1) * space / uint64(n+1)
+		for j := length - 1; j >= 0; j-- {
+			digits[j] = rankDigits[value%base]
+			value /= base
+		}
+		ranks[i] = strings.TrimRight(string(digits), rankDigits[:1])
+	}
+	return ranks
+}
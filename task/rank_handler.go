@@ -0,0 +1,70 @@
+package task
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// handleInvalidRank will respond each time a task can not be moved next to the requested task.
+func (h *HandlerImp) handleInvalidRank(w http.ResponseWriter) {
+	http.Error(w, "Invalid rank", http.StatusBadRequest)
+}
+
+// HandleRank will handle post requests moving a task right before or after another task in the manual order.
+// The query has the id of the task and either a before or an after id.
+func (h *HandlerImp) HandleRank(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	before := r.URL.Query().Get(string(PlaceBefore))
+	after := r.URL.Query().Get(string(PlaceAfter))
+	placement, value := PlaceBefore, before
+	if after != "" {
+		placement, value = PlaceAfter, after
+	}
+	targetId, err := uuid.Parse(value)
+	if err != nil || before != "" && after != "" {
+		h.handleInvalidRank(w)
+		return
+	}
+
+	task, undoToken, err := h.Service.RankTask(&token, &id, &targetId, placement)
+	if errors.Is(err, ErrInvalidRank) {
+		h.handleInvalidRank(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrForbidden) {
+		h.handleForbidden(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleRank: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	setUndoToken(w, undoToken)
+	h.writeTask(w, task)
+}
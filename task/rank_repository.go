@@ -0,0 +1,132 @@
+package task
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// GetLastRank will get the last rank of the tasks of a user, it is empty if the user has no tasks.
+func (r *PostgresRepository) GetLastRank(userId *uuid.UUID) (string, error) {
+	query := `SELECT COALESCE(MAX(rank COLLATE "C"), '') FROM tasks WHERE user_id = $1`
+	log.Printf("Executing query in task-PostgresRepository-GetLastRank: %s | Parameters %s", query, userId)
+
+	var rank string
+	err := r.database.QueryRow(query, *userId).Scan(&rank)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetLastRank: %v", err)
+		return "", err
+	}
+	return rank, nil
+}
+
+// GetAdjacentRank will get the rank of the task of a user that comes right before or after a rank, ignoring a task.
+// It is empty if no task comes before or after it.
+func (r *PostgresRepository) GetAdjacentRank(userId *uuid.UUID, rank string, placement Placement, taskId *uuid.UUID) (string, error) {
+	query := `SELECT COALESCE(MAX(rank COLLATE "C"), '') FROM tasks WHERE user_id = $1 AND rank COLLATE "C" < $2 AND id <> $3`
+	if placement == PlaceAfter {
+		query = `SELECT COALESCE(MIN(rank COLLATE "C"), '') FROM tasks WHERE user_id = $1 AND rank COLLATE "C" > $2 AND id <> $3`
+	}
+	log.Printf("Executing query in task-PostgresRepository-GetAdjacentRank: %s | Parameters %s, %s, %s", query, userId, rank, taskId)
+
+	var adjacent string
+	err := r.database.QueryRow(query, *userId, rank, *taskId).Scan(&adjacent)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetAdjacentRank: %v", err)
+		return "", err
+	}
+	return adjacent, nil
+}
+
+// SetRank will change the rank of a task owned by a user and return the updated task.
+func (r *PostgresRepository) SetRank(taskId *uuid.UUID, userId *uuid.UUID, rank string) (*Task, error) {
+	query := "UPDATE tasks SET rank = $1, version = version + 1 WHERE id = $2 AND user_id = $3 AND date_deleted IS NULL RETURNING " + selectTaskColumns("tasks")
+	log.Printf("Executing query in task-PostgresRepository-SetRank: %s | Parameters %s, %s, %s", query, rank, taskId, userId)
+
+	task, err := scanTask(r.database.QueryRow(query, rank, *taskId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-SetRank: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetLongRankUsers will get the users having a task with a rank longer than a length.
+func (r *PostgresRepository) GetLongRankUsers(length int) ([]uuid.UUID, error) {
+	query := "SELECT DISTINCT user_id FROM tasks WHERE length(rank) > $1"
+	log.Printf("Executing query in task-PostgresRepository-GetLongRankUsers: %s | Parameters %d", query, length)
+
+	rows, err := r.database.Query(query, length)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetLongRankUsers: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	userIds := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var userId uuid.UUID
+		err := rows.Scan(&userId)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetLongRankUsers: %v", err)
+			return nil, err
+		}
+		userIds = append(userIds, userId)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetLongRankUsers: %v", err)
+		return nil, err
+	}
+	return userIds, nil
+}
+
+// GetRankOrder will lock the tasks of a user and get their ids in the manual order.
+func (r *PostgresRepository) GetRankOrder(userId *uuid.UUID) ([]uuid.UUID, error) {
+	query := `SELECT id FROM tasks WHERE user_id = $1 ORDER BY rank COLLATE "C", id FOR UPDATE`
+	log.Printf("Executing query in task-PostgresRepository-GetRankOrder: %s | Parameters %s", query, userId)
+
+	rows, err := r.database.Query(query, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetRankOrder: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	taskIds := make([]uuid.UUID, 0)
+	for rows.Next() {
+		var taskId uuid.UUID
+		err := rows.Scan(&taskId)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetRankOrder: %v", err)
+			return nil, err
+		}
+		taskIds = append(taskIds, taskId)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetRankOrder: %v", err)
+		return nil, err
+	}
+	return taskIds, nil
+}
+
+// SetRanks will set the ranks of tasks owned by a user, the rank of a task has the same index as its id.
+// The versions are kept because the order of the tasks does not change.
+func (r *PostgresRepository) SetRanks(userId *uuid.UUID, taskIds []uuid.UUID, ranks []string) error {
+	query := "UPDATE tasks t SET rank = v.rank FROM unnest($1::uuid[], $2::text[]) AS v(id, rank) WHERE t.id = v.id AND t.user_id = $3"
+	log.Printf("Executing query in task-PostgresRepository-SetRanks: %s | Parameters %d tasks, %s", query, len(taskIds), userId)
+
+	_, err := r.database.Exec(query, pq.Array(uuidStrings(taskIds)), pq.Array(ranks), *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-SetRanks: %v", err)
+	}
+	return err
+}
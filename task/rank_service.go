@@ -0,0 +1,64 @@
+package task
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// RankTask will move a task right before or after another task of the same owner in the manual order
+// and return the token that moves it back. Only the rank of the moved task changes.
+func (s *ServiceImp) RankTask(tokenString *string, taskId *uuid.UUID, targetId *uuid.UUID, placement Placement) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-RankTask: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	if *taskId == *targetId || placement != PlaceBefore && placement != PlaceAfter {
+		return nil, "", ErrInvalidRank
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-RankTask: %v", err)
+		return nil, "", err
+	}
+
+	var task *Task
+	undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+		// the owner is locked so concurrent moves into the same gap do not get the same rank
+		err := service.Repository.LockUser(ownerId)
+		if err != nil {
+			return err
+		}
+
+		target, err := service.Repository.GetTask(targetId, ownerId)
+		if errors.Is(err, ErrTaskNotFound) {
+			return ErrInvalidRank
+		} else if err != nil {
+			return err
+		}
+
+		adjacent, err := service.Repository.GetAdjacentRank(ownerId, target.Rank, placement, taskId)
+		if err != nil {
+			return err
+		}
+
+		rank := rankBetween(adjacent, target.Rank)
+		if placement == PlaceAfter {
+			rank = rankBetween(target.Rank, adjacent)
+		}
+
+		task, err = service.Repository.SetRank(taskId, ownerId, rank)
+		return err
+	})
+	if errors.Is(err, ErrInvalidRank) {
+		return nil, "", err
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-RankTask: %v", err)
+		return nil, "", err
+	}
+	return task, undoToken, nil
+}
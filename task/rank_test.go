@@ -0,0 +1,104 @@
+package task
+
+import (
+	"strings"
+	"testing"
+)
+
+// validRank will check that a rank only has rank digits and does not end with the smallest one.
+func validRank(rank string) bool {
+	if rank == "" || rank[len(rank)-1] == rankDigits[0] {
+		return false
+	}
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		before string
+		after  string
+	}{
+		{"", ""},
+		{"i", ""},
+		{"z", ""},
+		{"zz", ""},
+		{"", "i"},
+		{"", "1"},
+		{"", "01"},
+		{"", "001"},
+		{"a", "c"},
+		{"a", "b"},
+		{"a", "a1"},
+		{"a", "a01"},
+		{"ai", "b"},
+		{"az", "b"},
+		{"azz", "b1"},
+		{"1", "2"},
+		{"0000000001i", "0000000002i"},
+		{"0000000001i", "0000000001j"},
+	}
+
+	for _, test := range tests {
+		rank := rankBetween(test.before, test.after)
+		if !validRank(rank) || rank <= test.before || test.after != "" && rank >= test.after {
+			t.Errorf("rankBetween(%q, %q) = %q", test.before, test.after, rank)
+		}
+	}
+}
+
+func TestRankBetweenRepeated(t *testing.T) {
+	tests := []struct {
+		name string
+		next func(before string, after string, rank string) (string, string)
+	}{
+		{"always after the new key", func(before string, after string, rank string) (string, string) { return rank, after }},
+		{"always before the new key", func(before string, after string, rank string) (string, string) { return before, rank }},
+		{"alternating", func(before string, after string, rank string) (string, string) {
+			if len(rank)%2 == 0 {
+				return rank, after
+			}
+			return before, rank
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before, after := "i", "j"
+			for i := 0; i < 200; i++ {
+				rank := rankBetween(before, after)
+				if !validRank(rank) || rank <= before || rank >= after {
+					t.Fatalf("rankBetween(%q, %q) = %q", before, after, rank)
+				}
+				before, after = test.next(before, after, rank)
+			}
+		})
+	}
+}
+
+func TestSpreadRanks(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 35, 36, 100, 1295, 1296, 5000} {
+		ranks := spreadRanks(n)
+		if len(ranks) != n {
+			t.Fatalf("spreadRanks(%d) returned %d ranks", n, len(ranks))
+		}
+
+		for i, rank := range ranks {
+			if !validRank(rank) {
+				t.Fatalf("spreadRanks(%d)[%d] = %q is not a valid rank", n, i, rank)
+			}
+			if i > 0 && ranks[i-1] >= rank {
+				t.Fatalf("spreadRanks(%d) is not ascending at %d: %q, %q", n, i, ranks[i-1], rank)
+			}
+		}
+
+		// every gap has room for a key at most one digit longer than the keys around it
+		if n > 1 && len(rankBetween(ranks[0], ranks[1])) > len(ranks[0])+1 {
+			t.Errorf("spreadRanks(%d) keys %q and %q are too close", n, ranks[0], ranks[1])
+		}
+	}
+}
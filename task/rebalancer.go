@@ -0,0 +1,81 @@
+package task
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rebalancer will respace the ranks of the tasks of a user once one of their rank keys got too long.
+// Moving tasks between the same two tasks over and over makes the keys longer, respacing keeps the order.
+type Rebalancer struct {
+	Repository Repository
+	// MaxLength is the longest rank key that does not need a rebalance.
+	MaxLength int
+	// Interval is how often the rank keys are checked.
+	Interval time.Duration
+}
+
+// rebalance will respace the ranks of every user having a key longer than the max length.
+func (b *Rebalancer) rebalance() {
+	userIds, err := b.Repository.GetLongRankUsers(b.MaxLength)
+	if err != nil {
+		log.Printf("Error in task-Rebalancer-rebalance: %v", err)
+		return
+	}
+
+	for _, userId := range userIds {
+		err := b.rebalanceUser(&userId)
+		if err != nil {
+			log.Printf("Error in task-Rebalancer-rebalance: %v", err)
+		}
+	}
+
+	if len(userIds) > 0 {
+		log.Printf("Rebalanced the task ranks of %d users", len(userIds))
+	}
+}
+
+// rebalanceUser will give the tasks of a user evenly spaced ranks in their current order.
+// The user is locked like in RankTask so no rank is computed from neighbours that are respaced at the same time.
+func (b *Rebalancer) rebalanceUser(userId *uuid.UUID) error {
+	return b.Repository.Transaction(func(tx Repository) error {
+		err := tx.LockUser(userId)
+		if err != nil {
+			return err
+		}
+
+		taskIds, err := tx.GetRankOrder(userId)
+		if err != nil {
+			return err
+		}
+		return tx.SetRanks(userId, taskIds, spreadRanks(len(taskIds)))
+	})
+}
+
+// Run will rebalance the ranks on every interval until the context is done.
+func (b *Rebalancer) Run(ctx context.Context) {
+	ticker := time.NewTicker(b.Interval)
+	defer ticker.Stop()
+
+	for {
+		b.rebalance()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// NewRebalancer will create a new rebalancer with a repository, max rank length and check interval.
+func NewRebalancer(repository Repository, maxLength int, interval time.Duration) Rebalancer {
+	return Rebalancer{
+		Repository: repository,
+		MaxLength:  maxLength,
+		Interval:   interval,
+	}
+}
@@ -0,0 +1,60 @@
+package task
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecurrenceScope defines which occurrences a change to a recurrence rule applies to.
+type RecurrenceScope string
+
+const (
+	// ScopeThis will move only this occurrence out of its series, the rest of the series is unchanged.
+	// With a new rule the occurrence is split off into a series of its own starting at its date.
+	ScopeThis RecurrenceScope = "this"
+	// ScopeFuture will change this occurrence and all later open occurrences of the series,
+	// which ends the old series right before this occurrence.
+	ScopeFuture RecurrenceScope = "future"
+)
+
+// Recurrence holds the recurrence of a task that is an occurrence of a series.
+type Recurrence struct {
+	Rule     string    `json:"rule"`
+	SeriesId uuid.UUID `json:"seriesId"`
+	// Date is the date the rule scheduled this occurrence on, the due date may be moved away from it.
+	Date time.Time `json:"date"`
+	// Index is the position of this occurrence in the series starting from 1.
+	Index int64 `json:"index"`
+}
+
+// RecurrenceUpdate is the body of a request changing the recurrence rule of a task.
+// An empty rule will stop the recurrence.
+type RecurrenceUpdate struct {
+	Rule string `json:"rule"`
+}
+
+// newRecurrence will create the recurrence of the first occurrence of a new series.
+func newRecurrence(rule *Rule, date time.Time) *Recurrence {
+	return &Recurrence{
+		Rule:     rule.String(),
+		SeriesId: uuid.New(),
+		Date:     date,
+		Index:    1,
+	}
+}
+
+// values will return the values of the recurrence columns, all of them are null for a nil recurrence.
+func (r *Recurrence) values() []any {
+	if r == nil {
+		return []any{sql.NullString{}, uuid.NullUUID{}, sql.NullTime{}, sql.NullInt64{}}
+	}
+
+	return []any{
+		sql.NullString{String: r.Rule, Valid: true},
+		uuid.NullUUID{UUID: r.SeriesId, Valid: true},
+		sql.NullTime{Time: r.Date, Valid: true},
+		sql.NullInt64{Int64: r.Index, Valid: true},
+	}
+}
@@ -2,63 +2,279 @@ package task
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// executor runs queries, it is implemented by both sql.DB and sql.Tx.
+type executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
 // PostgresRepository is an implementation of Repository.
 type PostgresRepository struct {
-	database *sql.DB
+	// database is the connection pool or, inside Transaction, the transaction.
+	database executor
+	db       *sql.DB
 }
 
-// countTasks will count all the tasks of a user.
-func (r *PostgresRepository) countTasks(id *uuid.UUID) (int64, error) {
-	query := "SELECT COUNT(id) FROM tasks WHERE user_id  = $1"
-	log.Printf("Executing query in task-PostgresRepository-countTasks: %s | Parameters %s", query, id.String())
-	row := r.database.QueryRow(query, *id)
+// taskColumns are the columns stored for every task.
+const taskColumns = "id, name, description, priority, due_date, date_completed, date_deleted, version, recurrence_rule, series_id, recurrence_date, recurrence_index, parent_id, list_id, rank, estimate_value, estimate_unit"
 
-	var count int64
-	err := row.Scan(&count)
-	if err != nil {
-		log.Printf("Error in task-PostgresRepsitory-countTasks: %v", err)
-		return 0, err
-	}
-	return count, nil
+// tagsColumn selects the tags of a task as a json array, %[1]s is the table or alias holding the task.
+const tagsColumn = "COALESCE((SELECT json_agg(json_build_object('id', tg.id, 'name', tg.name, 'color', tg.color) ORDER BY tg.name) " +
+	"FROM task_tags tt JOIN tags tg ON tg.id = tt.tag_id WHERE tt.task_id = %[1]s.id), '[]')"
+
+// commentCountColumn selects the number of comments of a task, %[1]s is the table or alias holding the task.
+const commentCountColumn = "(SELECT COUNT(*) FROM comments c WHERE c.task_id = %[1]s.id)"
+
+// blockedColumn selects if a task has a blocker that is neither completed nor in the trash, %[1]s is the table or alias holding the task.
+const blockedColumn = "EXISTS (SELECT 1 FROM task_dependencies td JOIN tasks b ON b.id = td.blocker_id " +
+	"WHERE td.task_id = %[1]s.id AND b.date_completed IS NULL AND b.date_deleted IS NULL)"
+
+// selectTaskColumns will return taskColumns and the computed columns of a task read from a table or alias.
+func selectTaskColumns(table string) string {
+	return taskColumns + ", " + fmt.Sprintf(tagsColumn, table) + ", " + fmt.Sprintf(commentCountColumn, table) + ", " + fmt.Sprintf(blockedColumn, table)
 }
 
-// GetTasks will get all tasks of a user.
-func (r *PostgresRepository) GetTasks(id *uuid.UUID) ([]Task, error) {
-	query := "SELECT id, name, description, priority, due_date, date_completed, date_deleted FROM tasks WHERE user_id = $1"
-	log.Printf("Executing query in task-PostgresRepository-GetTasks: %s | Parameters %s", query, id.String())
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
 
-	rows, err := r.database.Query(query, *id)
+// scanTask will scan a row selected with selectTaskColumns.
+func scanTask(row rowScanner) (*Task, error) {
+	var task Task
+	var rule sql.NullString
+	var seriesId uuid.NullUUID
+	var recurrenceDate sql.NullTime
+	var recurrenceIndex sql.NullInt64
+	var estimateValue sql.NullInt64
+	var estimateUnit sql.NullString
+	var tags []byte
+	err := row.Scan(&task.Id, &task.Name, &task.Description, &task.Priority, &task.DueDate, &task.DateCompleted, &task.DateDeleted, &task.Version,
+		&rule, &seriesId, &recurrenceDate, &recurrenceIndex, &task.ParentId, &task.ListId, &task.Rank, &estimateValue, &estimateUnit,
+		&tags, &task.CommentCount, &task.Blocked)
 	if err != nil {
-		log.Printf("Error in task-PostgresRepsitory-GetTasks: %v", err)
 		return nil, err
 	}
 
-	count, err := r.countTasks(id)
+	err = json.Unmarshal(tags, &task.Tags)
 	if err != nil {
-		log.Printf("Error in task-PostgresRepsitory-GetTasks: %v", err)
 		return nil, err
 	}
 
-	tasks := make([]Task, 0, count)
+	if rule.Valid {
+		task.Recurrence = &Recurrence{
+			Rule:     rule.String,
+			SeriesId: seriesId.UUID,
+			Date:     recurrenceDate.Time,
+			Index:    recurrenceIndex.Int64,
+		}
+	}
+
+	if estimateValue.Valid {
+		task.Estimate = &Estimate{Value: estimateValue.Int64, Unit: EstimateUnit(estimateUnit.String)}
+	}
+	return &task, nil
+}
+
+// qualifiedTaskColumns will return taskColumns prefixed with a table alias.
+func qualifiedTaskColumns(alias string) string {
+	columns := strings.Split(taskColumns, ", ")
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// sortColumns maps the sort fields to table columns.
+var sortColumns = map[SortField]string{
+	SortDueDate:  "due_date",
+	SortPriority: "priority",
+	SortName:     "name",
+	SortRank:     `rank COLLATE "C"`,
+}
+
+// buildTaskConditions will build the where conditions and arguments for a filter.
+func buildTaskConditions(id *uuid.UUID, filter *Filter) ([]string, []any) {
+	// The trash only holds the tasks of the user, the other listings include the tasks shared with them.
+	conditions := []string{"(user_id = $1 OR " + sharedTasksCondition + ")"}
+	if filter.Status == StatusDeleted {
+		conditions[0] = "user_id = $1"
+	}
+	args := []any{*id}
+
+	addArg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	switch filter.Status {
+	case StatusAny:
+		conditions = append(conditions, "date_deleted IS NULL")
+	case StatusActive:
+		conditions = append(conditions, "date_completed IS NULL", "date_deleted IS NULL")
+	case StatusCompleted:
+		conditions = append(conditions, "date_completed IS NOT NULL", "date_deleted IS NULL")
+	case StatusDeleted:
+		conditions = append(conditions, "date_deleted IS NOT NULL")
+	}
+
+	if filter.Priority != nil {
+		conditions = append(conditions, "priority = "+addArg(*filter.Priority))
+	}
+	if filter.DueFrom != nil {
+		conditions = append(conditions, "due_date >= "+addArg(*filter.DueFrom))
+	}
+	if filter.DueTo != nil {
+		conditions = append(conditions, "due_date <= "+addArg(*filter.DueTo))
+	}
+
+	if filter.ListId != nil {
+		conditions = append(conditions, "list_id = "+addArg(*filter.ListId))
+	} else {
+		conditions = append(conditions, "list_id NOT IN (SELECT id FROM lists WHERE archived = true)")
+	}
+
+	if len(filter.Tags) > 0 {
+		tags := addArg(pq.Array(uuidStrings(filter.Tags)))
+		if filter.MatchAllTags {
+			conditions = append(conditions, fmt.Sprintf("id IN (SELECT task_id FROM task_tags WHERE tag_id = ANY(%s::uuid[]) GROUP BY task_id HAVING COUNT(DISTINCT tag_id) = cardinality(%s::uuid[]))", tags, tags))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("id IN (SELECT task_id FROM task_tags WHERE tag_id = ANY(%s::uuid[]))", tags))
+		}
+	}
+
+	if filter.After != nil {
+		var value any
+		switch filter.Sort {
+		case SortDueDate:
+			value = filter.After.DueDate
+		case SortPriority:
+			value = filter.After.Priority
+		case SortName:
+			value = filter.After.Name
+		case SortRank:
+			value = filter.After.Rank
+		}
+
+		comparison := ">"
+		if filter.Descending {
+			comparison = "<"
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)", sortColumns[filter.Sort], comparison, addArg(value), addArg(filter.After.Id)))
+	}
+
+	return conditions, args
+}
+
+// GetTasks will get the tasks of a user matching a filter.
+// One task more than the limit is returned so the caller knows if there is a next page.
+func (r *PostgresRepository) GetTasks(id *uuid.UUID, filter *Filter) ([]Task, error) {
+	conditions, args := buildTaskConditions(id, filter)
+
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	column := sortColumns[filter.Sort]
+	args = append(args, filter.Limit+1)
 
+	query := fmt.Sprintf("SELECT %s FROM tasks WHERE %s ORDER BY %s %s, id %s LIMIT $%d",
+		selectTaskColumns("tasks"), strings.Join(conditions, " AND "), column, direction, direction, len(args))
+	log.Printf("Executing query in task-PostgresRepository-GetTasks: %s | Parameters %v", query, args)
+
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetTasks: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]Task, 0, filter.Limit+1)
 	for rows.Next() {
-		var task Task
-		err = rows.Scan(&task.Id, &task.Name, &task.Description, &task.Priority, &task.DueDate, &task.DateCompleted, &task.DateDeleted)
+		task, err := scanTask(rows)
 		if err != nil {
-			log.Printf("Error in task-PostgresRepsitory-GetTasks: %v", err)
+			log.Printf("Error in task-PostgresRepository-GetTasks: %v", err)
+			return nil, err
 		}
 
-		tasks = append(tasks, task)
+		tasks = append(tasks, *task)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetTasks: %v", err)
+		return nil, err
 	}
 
 	return tasks, nil
 }
 
+// searchScanner scans the columns of a task followed by the rank and highlights of a search result.
+type searchScanner struct {
+	row    rowScanner
+	result *SearchResult
+}
+
+// Scan will scan the task into dest and the search columns into the result.
+func (s searchScanner) Scan(dest ...any) error {
+	return s.row.Scan(append(dest, &s.result.Rank, &s.result.Highlights.Name, &s.result.Highlights.Description)...)
+}
+
+// SearchTasks will get the tasks of a user matching a filter and a tsquery ordered by rank.
+func (r *PostgresRepository) SearchTasks(id *uuid.UUID, filter *Filter, search string) ([]SearchResult, error) {
+	conditions, args := buildTaskConditions(id, filter)
+	args = append(args, search)
+	conditions = append(conditions, fmt.Sprintf("%s @@ search_query", searchDocument))
+	searchArg := len(args)
+	args = append(args, filter.Limit)
+
+	options := fmt.Sprintf("StartSel=%s, StopSel=%s", HighlightStart, HighlightStop)
+	query := fmt.Sprintf("SELECT %s, ts_rank(%s, search_query) AS search_rank, ts_headline('%s', name, search_query, '%s, HighlightAll=true'), "+
+		"ts_headline('%s', description, search_query, '%s, MaxFragments=2, MaxWords=20, MinWords=5') "+
+		"FROM tasks, to_tsquery('%s', $%d) search_query WHERE %s ORDER BY search_rank DESC, id LIMIT $%d",
+		selectTaskColumns("tasks"), searchDocument, searchConfig, options, searchConfig, options,
+		searchConfig, searchArg, strings.Join(conditions, " AND "), len(args))
+	log.Printf("Executing query in task-PostgresRepository-SearchTasks: %s | Parameters %v", query, args)
+
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-SearchTasks: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]SearchResult, 0, filter.Limit)
+	for rows.Next() {
+		var result SearchResult
+		task, err := scanTask(searchScanner{rows, &result})
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-SearchTasks: %v", err)
+			return nil, err
+		}
+		result.Task = *task
+		results = append(results, result)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-SearchTasks: %v", err)
+		return nil, err
+	}
+	return results, nil
+}
+
 // CheckPriority will check if a priority is found in the database.
 func (r *PostgresRepository) CheckPriority(priority *int64) (bool, error) {
 	query := "SELECT COUNT(id) FROM task_priorities WHERE id = $1"
@@ -76,38 +292,499 @@ func (r *PostgresRepository) CheckPriority(priority *int64) (bool, error) {
 	return count > 0, nil
 }
 
+// FindPriority will find the id of a priority by its name ignoring case.
+func (r *PostgresRepository) FindPriority(name string) (*int64, error) {
+	query := "SELECT id FROM task_priorities WHERE lower(name) = lower($1)"
+	log.Printf("Executing query in task-PostgresRepository-FindPriority: %s | Parameters %s", query, name)
+
+	var id int64
+	err := r.database.QueryRow(query, name).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrInvalidPriority
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-FindPriority: %v", err)
+		return nil, err
+	}
+	return &id, nil
+}
+
 // AddTask will add a new task to a user.
 func (r *PostgresRepository) AddTask(task *Task, id *uuid.UUID) error {
-	query := "INSERT INTO tasks(id, name, description, priority, due_date, date_completed, date_deleted, user_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)"
-	log.Printf("Executing query in task-PostgresRepository-AddTask: %s | Parameters %s, %s, %s, %d, %s, %s, %s, %s", query, task.Id, task.Name, task.Description, task.Priority, task.DueDate, task.DateCompleted, task.DateDeleted, id)
+	query := "INSERT INTO tasks(id, name, description, priority, due_date, date_completed, date_deleted, version, user_id, parent_id, list_id, rank, recurrence_rule, series_id, recurrence_date, recurrence_index, estimate_value, estimate_unit) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)"
+	args := append([]any{task.Id, task.Name, task.Description, task.Priority, task.DueDate, task.DateCompleted, task.DateDeleted, task.Version, *id, task.ParentId, task.ListId, task.Rank}, append(task.Recurrence.values(), task.Estimate.values()...)...)
+	log.Printf("Executing query in task-PostgresRepository-AddTask: %s | Parameters %v", query, args)
 
-	_, err := r.database.Exec(query, task.Id, task.Name, task.Description, task.Priority, task.DueDate, task.DateCompleted, task.DateDeleted, *id)
+	_, err := r.database.Exec(query, args...)
 	if err != nil {
 		log.Printf("Error in task-PostgresRepsitory-AddTasks: %v", err)
 	}
 	return err
 }
 
-// UpdateTask will update an existing task.
-func (r *PostgresRepository) UpdateTask(task *Task) error {
-	query := "UPDATE tasks SET name = $1, description = $2, priority = $3, due_date = $4, date_completed = $5, date_deleted = $6 WHERE id = $7"
-	log.Printf("Execting query in task-PostgresRepository-UpdateTask: %s | Parameters %s, %s, %d, %s, %s, %s, %s, ", query, task.Name, task.Description, task.Priority, task.DueDate, task.DateCompleted, task.DateDeleted, task.Id)
+// missingTaskError will find out why a conditional write on a task owned by a user that is not in the trash did not match any row.
+// It returns ErrVersionMismatch if the task exists outside the trash and ErrTaskNotFound otherwise.
+func (r *PostgresRepository) missingTaskError(taskId *uuid.UUID, userId *uuid.UUID) error {
+	query := "SELECT COUNT(id) FROM tasks WHERE id = $1 AND user_id = $2 AND date_deleted IS NULL"
+	log.Printf("Executing query in task-PostgresRepository-missingTaskError: %s | Parameters %s, %s", query, taskId, userId)
+
+	var count int64
+	err := r.database.QueryRow(query, *taskId, *userId).Scan(&count)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-missingTaskError: %v", err)
+		return err
+	}
+
+	if count > 0 {
+		return ErrVersionMismatch
+	}
+	return ErrTaskNotFound
+}
+
+// UpdateTask will update an existing task owned by a user that is not in the trash and return the updated task.
+// The trash date is never written, tasks are only moved in and out of the trash by DeleteTask and RestoreTask.
+// If version is not nil the task is updated only if its version matches.
+func (r *PostgresRepository) UpdateTask(task *Task, userId *uuid.UUID, version *int64) (*Task, error) {
+	args := append([]any{task.Name, task.Description, task.Priority, task.DueDate, task.DateCompleted, task.Id, *userId}, task.Estimate.values()...)
+	query := "UPDATE tasks SET name = $1, description = $2, priority = $3, due_date = $4, date_completed = $5, " +
+		"estimate_value = $8, estimate_unit = $9, version = version + 1 WHERE id = $6 AND user_id = $7 AND date_deleted IS NULL"
+	if version != nil {
+		args = append(args, *version)
+		query += " AND version = $10"
+	}
+	query += " RETURNING " + selectTaskColumns("tasks")
+	log.Printf("Executing query in task-PostgresRepository-UpdateTask: %s | Parameters %v", query, args)
+
+	updatedTask, err := scanTask(r.database.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) && version != nil {
+		return nil, r.missingTaskError(&task.Id, userId)
+	} else if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-UpdateTask: %v", err)
+		return nil, err
+	}
+	return updatedTask, nil
+}
+
+// DeleteTask will move an existing task owned by a user to the trash, a task already in the trash is not found.
+// If version is not nil the task is deleted only if its version matches.
+func (r *PostgresRepository) DeleteTask(taskId *uuid.UUID, userId *uuid.UUID, dateDeleted *time.Time, version *int64) error {
+	args := []any{*dateDeleted, *taskId, *userId}
+	query := "UPDATE tasks SET date_deleted = $1, version = version + 1 WHERE id = $2 AND user_id = $3 AND date_deleted IS NULL"
+	if version != nil {
+		args = append(args, *version)
+		query += " AND version = $4"
+	}
+	log.Printf("Executing query in task-PostgresRepository-DeleteTask: %s | Parameters %v", query, args)
+
+	result, err := r.database.Exec(query, args...)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteTask: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteTask: %v", err)
+		return err
+	}
+
+	if count == 0 {
+		return r.missingTaskError(taskId, userId)
+	}
+	return nil
+}
+
+// RestoreTask will move a task owned by a user out of the trash and return the restored task.
+// A task whose parent is still in the trash is restored as a top level task.
+func (r *PostgresRepository) RestoreTask(taskId *uuid.UUID, userId *uuid.UUID) (*Task, error) {
+	query := "UPDATE tasks SET date_deleted = NULL, version = version + 1, parent_id = (SELECT p.id FROM tasks p WHERE p.id = tasks.parent_id AND p.date_deleted IS NULL) WHERE id = $1 AND user_id = $2 AND date_deleted IS NOT NULL RETURNING " + selectTaskColumns("tasks")
+	log.Printf("Executing query in task-PostgresRepository-RestoreTask: %s | Parameters %s, %s", query, taskId, userId)
+
+	task, err := scanTask(r.database.QueryRow(query, *taskId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-RestoreTask: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// EmptyTrash will permanently delete all tasks of a user that are in the trash.
+func (r *PostgresRepository) EmptyTrash(userId *uuid.UUID) error {
+	query := "DELETE FROM tasks WHERE user_id = $1 AND date_deleted IS NOT NULL"
+	log.Printf("Executing query in task-PostgresRepository-EmptyTrash: %s | Parameters %s", query, userId)
+
+	_, err := r.database.Exec(query, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-EmptyTrash: %v", err)
+	}
+	return err
+}
+
+// PurgeTasks will permanently delete all tasks moved to the trash before a date and return how many were deleted.
+func (r *PostgresRepository) PurgeTasks(before *time.Time) (int64, error) {
+	query := "DELETE FROM tasks WHERE date_deleted IS NOT NULL AND date_deleted < $1"
+	log.Printf("Executing query in task-PostgresRepository-PurgeTasks: %s | Parameters %s", query, before)
+
+	result, err := r.database.Exec(query, *before)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-PurgeTasks: %v", err)
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-PurgeTasks: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// CompleteTask will set the completion date of an open task owned by a user and return the updated task.
+// It returns ErrTaskNotFound if there is no such open task.
+func (r *PostgresRepository) CompleteTask(taskId *uuid.UUID, userId *uuid.UUID, dateCompleted *time.Time) (*Task, error) {
+	query := "UPDATE tasks SET date_completed = $1, version = version + 1 WHERE id = $2 AND user_id = $3 AND date_completed IS NULL AND date_deleted IS NULL RETURNING " + selectTaskColumns("tasks")
+	log.Printf("Executing query in task-PostgresRepository-CompleteTask: %s | Parameters %s, %s, %s", query, dateCompleted, taskId, userId)
+
+	task, err := scanTask(r.database.QueryRow(query, *dateCompleted, *taskId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-CompleteTask: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// UncompleteTask will clear the completion date of a task owned by a user and return the updated task.
+func (r *PostgresRepository) UncompleteTask(taskId *uuid.UUID, userId *uuid.UUID) (*Task, error) {
+	query := "UPDATE tasks SET date_completed = NULL, version = version + 1 WHERE id = $1 AND user_id = $2 AND date_deleted IS NULL RETURNING " + selectTaskColumns("tasks")
+	log.Printf("Executing query in task-PostgresRepository-UncompleteTask: %s | Parameters %s, %s", query, taskId, userId)
+
+	task, err := scanTask(r.database.QueryRow(query, *taskId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-UncompleteTask: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// GetTask will get a task owned by a user that is not in the trash.
+func (r *PostgresRepository) GetTask(taskId *uuid.UUID, userId *uuid.UUID) (*Task, error) {
+	query := "SELECT " + selectTaskColumns("tasks") + " FROM tasks WHERE id = $1 AND user_id = $2 AND date_deleted IS NULL"
+	log.Printf("Executing query in task-PostgresRepository-GetTask: %s | Parameters %s, %s", query, taskId, userId)
+
+	task, err := scanTask(r.database.QueryRow(query, *taskId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetTask: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// PatchTask will update only the columns changed by a patch on a task owned by a user and return the updated task.
+// If version is not nil the task is updated only if its version matches.
+func (r *PostgresRepository) PatchTask(taskId *uuid.UUID, userId *uuid.UUID, patch *Patch, version *int64) (*Task, error) {
+	var assignments []string
+	var args []any
+	set := func(column string, value any) {
+		args = append(args, value)
+		assignments = append(assignments, fmt.Sprintf("%s = $%d", column, len(args)))
+	}
+
+	if patch.Name != nil {
+		set("name", *patch.Name)
+	}
+	if patch.Description != nil {
+		set("description", *patch.Description)
+	}
+	if patch.Priority != nil {
+		set("priority", *patch.Priority)
+	}
+	if patch.DueDate != nil {
+		set("due_date", *patch.DueDate)
+	}
+	if patch.DateCompleted != nil {
+		set("date_completed", *patch.DateCompleted)
+	}
+	if patch.Estimate != nil {
+		values := (*patch.Estimate).values()
+		set("estimate_value", values[0])
+		set("estimate_unit", values[1])
+	}
+
+	assignments = append(assignments, "version = version + 1")
+
+	args = append(args, *taskId, *userId)
+	query := fmt.Sprintf("UPDATE tasks SET %s WHERE id = $%d AND user_id = $%d AND date_deleted IS NULL",
+		strings.Join(assignments, ", "), len(args)-1, len(args))
+	if version != nil {
+		args = append(args, *version)
+		query += fmt.Sprintf(" AND version = $%d", len(args))
+	}
+	query += " RETURNING " + selectTaskColumns("tasks")
+	log.Printf("Executing query in task-PostgresRepository-PatchTask: %s | Parameters %v", query, args)
+
+	task, err := scanTask(r.database.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) && version != nil {
+		return nil, r.missingTaskError(taskId, userId)
+	} else if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-PatchTask: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// SetRecurrence will set the recurrence of a task owned by a user and return the updated task.
+// A nil recurrence will remove the task from its series.
+func (r *PostgresRepository) SetRecurrence(taskId *uuid.UUID, userId *uuid.UUID, recurrence *Recurrence) (*Task, error) {
+	query := "UPDATE tasks SET recurrence_rule = $1, series_id = $2, recurrence_date = $3, recurrence_index = $4, version = version + 1 WHERE id = $5 AND user_id = $6 AND date_deleted IS NULL RETURNING " + selectTaskColumns("tasks")
+	args := append(recurrence.values(), *taskId, *userId)
+	log.Printf("Executing query in task-PostgresRepository-SetRecurrence: %s | Parameters %v", query, args)
+
+	task, err := scanTask(r.database.QueryRow(query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-SetRecurrence: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// UpdateFutureOccurrences will move the open occurrences scheduled after an occurrence to the series of a recurrence.
+// Their indexes continue from the index of the recurrence. A nil recurrence will remove them from their series.
+func (r *PostgresRepository) UpdateFutureOccurrences(occurrence *Task, userId *uuid.UUID, recurrence *Recurrence) error {
+	var query string
+	var args []any
+	if recurrence == nil {
+		query = "UPDATE tasks SET recurrence_rule = NULL, series_id = NULL, recurrence_date = NULL, recurrence_index = NULL, version = version + 1 WHERE user_id = $1 AND series_id = $2 AND recurrence_date > $3 AND date_completed IS NULL AND date_deleted IS NULL"
+		args = []any{*userId, occurrence.Recurrence.SeriesId, occurrence.Recurrence.Date}
+	} else {
+		query = "UPDATE tasks SET recurrence_rule = $1, series_id = $2, recurrence_index = recurrence_index - $3 + $4, version = version + 1 WHERE user_id = $5 AND series_id = $6 AND recurrence_date > $7 AND date_completed IS NULL AND date_deleted IS NULL"
+		args = []any{recurrence.Rule, recurrence.SeriesId, occurrence.Recurrence.Index, recurrence.Index, *userId, occurrence.Recurrence.SeriesId, occurrence.Recurrence.Date}
+	}
+	log.Printf("Executing query in task-PostgresRepository-UpdateFutureOccurrences: %s | Parameters %v", query, args)
+
+	_, err := r.database.Exec(query, args...)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-UpdateFutureOccurrences: %v", err)
+	}
+	return err
+}
+
+// descendantsQuery selects the ids of all descendants of the task $1 owned by the user $2.
+// UNION stops at ids that were already found so it ends even if the parents form a cycle.
+const descendantsQuery = "WITH RECURSIVE descendants AS (" +
+	"SELECT id FROM tasks WHERE parent_id = $1 AND user_id = $2 " +
+	"UNION SELECT t.id FROM tasks t JOIN descendants d ON t.parent_id = d.id) "
+
+// GetSubtree will get a task owned by a user and all its descendants that are not in the trash.
+// The task is the first element of the slice, a task that closes a cycle of parents is not returned again.
+func (r *PostgresRepository) GetSubtree(taskId *uuid.UUID, userId *uuid.UUID) ([]Task, error) {
+	query := "WITH RECURSIVE subtree AS (" +
+		"SELECT " + taskColumns + ", 0 AS level FROM tasks WHERE id = $1 AND user_id = $2 AND date_deleted IS NULL " +
+		"UNION ALL SELECT " + qualifiedTaskColumns("t") + ", s.level + 1 FROM tasks t JOIN subtree s ON t.parent_id = s.id WHERE t.date_deleted IS NULL) " +
+		"CYCLE id SET is_cycle USING path " +
+		"SELECT " + selectTaskColumns("subtree") + " FROM subtree WHERE NOT is_cycle ORDER BY level, due_date, id"
+	log.Printf("Executing query in task-PostgresRepository-GetSubtree: %s | Parameters %s, %s", query, taskId, userId)
+
+	rows, err := r.database.Query(query, *taskId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetSubtree: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []Task
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetSubtree: %v", err)
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetSubtree: %v", err)
+		return nil, err
+	}
+
+	if len(tasks) == 0 {
+		return nil, ErrTaskNotFound
+	}
+	return tasks, nil
+}
+
+// GetDepth will return the depth of a task owned by a user that is not in the trash, top level tasks have a depth of 1.
+// The depth is the number of distinct ancestors, so it stays finite even if the parents form a cycle.
+func (r *PostgresRepository) GetDepth(taskId *uuid.UUID, userId *uuid.UUID) (int, error) {
+	query := "WITH RECURSIVE ancestors AS (" +
+		"SELECT id, parent_id FROM tasks WHERE id = $1 AND user_id = $2 AND date_deleted IS NULL " +
+		"UNION SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id) " +
+		"SELECT count(*) FROM ancestors"
+	log.Printf("Executing query in task-PostgresRepository-GetDepth: %s | Parameters %s, %s", query, taskId, userId)
+
+	var depth int
+	err := r.database.QueryRow(query, *taskId, *userId).Scan(&depth)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetDepth: %v", err)
+		return 0, err
+	}
+
+	if depth == 0 {
+		return 0, ErrTaskNotFound
+	}
+	return depth, nil
+}
+
+// MoveTask will change the parent of a task owned by a user and return the moved task.
+// An invalid parent id will make the task a top level task.
+func (r *PostgresRepository) MoveTask(taskId *uuid.UUID, userId *uuid.UUID, parentId *uuid.NullUUID) (*Task, error) {
+	query := "UPDATE tasks SET parent_id = $1, version = version + 1 WHERE id = $2 AND user_id = $3 AND date_deleted IS NULL RETURNING " + selectTaskColumns("tasks")
+	log.Printf("Executing query in task-PostgresRepository-MoveTask: %s | Parameters %v, %s, %s", query, parentId, taskId, userId)
+
+	task, err := scanTask(r.database.QueryRow(query, *parentId, *taskId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-MoveTask: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// CompleteDescendants will set the completion date of all open descendants of a task owned by a user.
+func (r *PostgresRepository) CompleteDescendants(taskId *uuid.UUID, userId *uuid.UUID, dateCompleted *time.Time) error {
+	query := descendantsQuery + "UPDATE tasks SET date_completed = $3, version = version + 1 WHERE id IN (SELECT id FROM descendants) AND date_completed IS NULL AND date_deleted IS NULL"
+	log.Printf("Executing query in task-PostgresRepository-CompleteDescendants: %s | Parameters %s, %s, %s", query, taskId, userId, dateCompleted)
+
+	_, err := r.database.Exec(query, *taskId, *userId, *dateCompleted)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-CompleteDescendants: %v", err)
+	}
+	return err
+}
+
+// DeleteDescendants will move all descendants of a task owned by a user to the trash.
+func (r *PostgresRepository) DeleteDescendants(taskId *uuid.UUID, userId *uuid.UUID, dateDeleted *time.Time) error {
+	query := descendantsQuery + "UPDATE tasks SET date_deleted = $3, version = version + 1 WHERE id IN (SELECT id FROM descendants) AND date_deleted IS NULL"
+	log.Printf("Executing query in task-PostgresRepository-DeleteDescendants: %s | Parameters %s, %s, %s", query, taskId, userId, dateDeleted)
+
+	_, err := r.database.Exec(query, *taskId, *userId, *dateDeleted)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteDescendants: %v", err)
+	}
+	return err
+}
+
+// RestoreDescendants will move the descendants of a task owned by a user out of the trash
+// if they were moved to the trash together with it.
+func (r *PostgresRepository) RestoreDescendants(taskId *uuid.UUID, userId *uuid.UUID) error {
+	query := descendantsQuery + "UPDATE tasks SET date_deleted = NULL, version = version + 1 WHERE id IN (SELECT id FROM descendants) " +
+		"AND date_deleted = (SELECT date_deleted FROM tasks WHERE id = $1 AND user_id = $2)"
+	log.Printf("Executing query in task-PostgresRepository-RestoreDescendants: %s | Parameters %s, %s", query, taskId, userId)
+
+	_, err := r.database.Exec(query, *taskId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-RestoreDescendants: %v", err)
+	}
+	return err
+}
+
+// ReparentChildren will move the children of a task owned by a user to the parent of the task.
+func (r *PostgresRepository) ReparentChildren(taskId *uuid.UUID, userId *uuid.UUID) error {
+	query := "UPDATE tasks SET parent_id = (SELECT parent_id FROM tasks WHERE id = $1), version = version + 1 WHERE parent_id = $1 AND user_id = $2"
+	log.Printf("Executing query in task-PostgresRepository-ReparentChildren: %s | Parameters %s, %s", query, taskId, userId)
 
-	_, err := r.database.Exec(query, task.Name, task.Description, task.Priority, task.DueDate, task.DateCompleted, task.DateDeleted, task.Id)
+	_, err := r.database.Exec(query, *taskId, *userId)
 	if err != nil {
-		log.Printf("Error in task-PostgresRepsitory-UpdateTasks: %v", err)
+		log.Printf("Error in task-PostgresRepository-ReparentChildren: %v", err)
 	}
 	return err
 }
 
-// DeleteTask will delete an existing task.
-func (r *PostgresRepository) DeleteTask(id *uuid.UUID) error {
-	query := "DELETE FROM tasks WHERE id = $1"
-	log.Printf("Executing query in task-PostgresRepository-DeleteTask: %s | Parameters %s", query, id)
+// Transaction will call fn with a repository running every query in one transaction.
+// The transaction is committed if fn returns nil and rolled back otherwise.
+// Inside a transaction a savepoint is used instead, so a failed fn only undoes its own changes.
+func (r *PostgresRepository) Transaction(fn func(Repository) error) error {
+	if tx, ok := r.database.(*sql.Tx); ok {
+		return r.savepoint(tx, fn)
+	}
+
+	log.Printf("Executing query in task-PostgresRepository-Transaction: BEGIN")
+	tx, err := r.db.Begin()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-Transaction: %v", err)
+		return err
+	}
+
+	err = fn(&PostgresRepository{database: tx, db: r.db})
+	if err != nil {
+		log.Printf("Executing query in task-PostgresRepository-Transaction: ROLLBACK")
+		rollbackErr := tx.Rollback()
+		if rollbackErr != nil {
+			log.Printf("Error in task-PostgresRepository-Transaction: %v", rollbackErr)
+		}
+		return err
+	}
+
+	log.Printf("Executing query in task-PostgresRepository-Transaction: COMMIT")
+	err = tx.Commit()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-Transaction: %v", err)
+	}
+	return err
+}
+
+// LockUser will lock the row of a user until the transaction ends.
+// Changes that read several tasks of a user before writing one of them take it first, so they run one at a time.
+func (r *PostgresRepository) LockUser(userId *uuid.UUID) error {
+	query := "SELECT id FROM users WHERE id = $1 FOR UPDATE"
+	log.Printf("Executing query in task-PostgresRepository-LockUser: %s | Parameters %s", query, userId)
+
+	_, err := r.database.Exec(query, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-LockUser: %v", err)
+	}
+	return err
+}
+
+// savepoint will call fn inside a savepoint of a transaction and roll back to it if fn fails.
+// Nested savepoints share a name, so a savepoint is always released after it was rolled back to,
+// otherwise the rollback of an enclosing savepoint would only go back to the inner one.
+func (r *PostgresRepository) savepoint(tx *sql.Tx, fn func(Repository) error) error {
+	_, err := tx.Exec("SAVEPOINT task_savepoint")
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-savepoint: %v", err)
+		return err
+	}
+
+	err = fn(r)
+	if err != nil {
+		_, rollbackErr := tx.Exec("ROLLBACK TO SAVEPOINT task_savepoint")
+		if rollbackErr == nil {
+			_, rollbackErr = tx.Exec("RELEASE SAVEPOINT task_savepoint")
+		}
+		if rollbackErr != nil {
+			log.Printf("Error in task-PostgresRepository-savepoint: %v", rollbackErr)
+		}
+		return err
+	}
 
-	_, err := r.database.Exec(query, *id)
+	_, err = tx.Exec("RELEASE SAVEPOINT task_savepoint")
 	if err != nil {
-		log.Printf("Error in task-PostgresRepsitory-DeleteTasks: %v", err)
+		log.Printf("Error in task-PostgresRepository-savepoint: %v", err)
 	}
 	return err
 }
@@ -116,5 +793,6 @@ func (r *PostgresRepository) DeleteTask(id *uuid.UUID) error {
 func NewRepository(db *sql.DB) PostgresRepository {
 	return PostgresRepository{
 		database: db,
+		db:       db,
 	}
 }
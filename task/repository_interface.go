@@ -1,21 +1,239 @@
 package task
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // Repository defines methods for task repository.
 type Repository interface {
-	// GetTasks will get all task of a user.
-	GetTasks(*uuid.UUID) ([]Task, error)
+	// GetTasks will get the tasks of a user matching a filter.
+	GetTasks(*uuid.UUID, *Filter) ([]Task, error)
+
+	// SearchTasks will get the tasks of a user matching a filter and a tsquery ordered by rank.
+	SearchTasks(*uuid.UUID, *Filter, string) ([]SearchResult, error)
 
 	// CheckPriority will check if the priority us valid.
 	CheckPriority(*int64) (bool, error)
 
+	// FindPriority will find the id of a priority by its name.
+	FindPriority(string) (*int64, error)
+
 	// AddTask will add a new task to a user.
 	AddTask(*Task, *uuid.UUID) error
 
-	// UpdateTask will update an existing task.
-	UpdateTask(*Task) error
+	// UpdateTask will update an existing task owned by a user that is not in the trash if the version matches.
+	UpdateTask(*Task, *uuid.UUID, *int64) (*Task, error)
+
+	// DeleteTask will move a task owned by a user that is not in the trash yet to the trash if the version matches.
+	DeleteTask(*uuid.UUID, *uuid.UUID, *time.Time, *int64) error
+
+	// RestoreTask will move a task owned by a user out of the trash.
+	RestoreTask(*uuid.UUID, *uuid.UUID) (*Task, error)
+
+	// EmptyTrash will permanently delete all tasks of a user in the trash.
+	EmptyTrash(*uuid.UUID) error
+
+	// PurgeTasks will permanently delete all tasks moved to the trash before a date.
+	PurgeTasks(*time.Time) (int64, error)
+
+	// CompleteTask will set the completion date of an open task owned by a user.
+	CompleteTask(*uuid.UUID, *uuid.UUID, *time.Time) (*Task, error)
+
+	// UncompleteTask will clear the completion date of a task owned by a user.
+	UncompleteTask(*uuid.UUID, *uuid.UUID) (*Task, error)
+
+	// GetTask will get a task owned by a user that is not in the trash.
+	GetTask(*uuid.UUID, *uuid.UUID) (*Task, error)
+
+	// PatchTask will update only the fields changed by a patch on a task owned by a user if the version matches.
+	PatchTask(*uuid.UUID, *uuid.UUID, *Patch, *int64) (*Task, error)
+
+	// SetRecurrence will set the recurrence of a task owned by a user.
+	SetRecurrence(*uuid.UUID, *uuid.UUID, *Recurrence) (*Task, error)
+
+	// UpdateFutureOccurrences will move the open occurrences scheduled after an occurrence to the series of a recurrence.
+	UpdateFutureOccurrences(*Task, *uuid.UUID, *Recurrence) error
+
+	// GetSubtree will get a task owned by a user and all its descendants, the task is the first element.
+	GetSubtree(*uuid.UUID, *uuid.UUID) ([]Task, error)
+
+	// GetDepth will return the depth of a task owned by a user, top level tasks have a depth of 1.
+	GetDepth(*uuid.UUID, *uuid.UUID) (int, error)
+
+	// MoveTask will change the parent of a task owned by a user.
+	MoveTask(*uuid.UUID, *uuid.UUID, *uuid.NullUUID) (*Task, error)
+
+	// CompleteDescendants will set the completion date of all open descendants of a task owned by a user.
+	CompleteDescendants(*uuid.UUID, *uuid.UUID, *time.Time) error
+
+	// DeleteDescendants will move all descendants of a task owned by a user to the trash.
+	DeleteDescendants(*uuid.UUID, *uuid.UUID, *time.Time) error
+
+	// RestoreDescendants will move the descendants deleted together with a task owned by a user out of the trash.
+	RestoreDescendants(*uuid.UUID, *uuid.UUID) error
+
+	// ReparentChildren will move the children of a task owned by a user to the parent of the task.
+	ReparentChildren(*uuid.UUID, *uuid.UUID) error
+
+	// GetTags will get all tags of a user.
+	GetTags(*uuid.UUID) ([]Tag, error)
+
+	// CheckTagName will check if a user has no other tag with a name, the tag with the last id is excluded.
+	CheckTagName(*uuid.UUID, *string, *uuid.UUID) (bool, error)
+
+	// CountTags will count how many of the tags belong to a user.
+	CountTags(*uuid.UUID, []uuid.UUID) (int, error)
+
+	// AddTag will add a new tag to a user.
+	AddTag(*Tag, *uuid.UUID) error
+
+	// UpdateTag will rename and recolor a tag owned by a user.
+	UpdateTag(*Tag, *uuid.UUID) error
+
+	// DeleteTag will delete a tag owned by a user.
+	DeleteTag(*uuid.UUID, *uuid.UUID) error
+
+	// AttachTags will attach tags to a task owned by a user.
+	AttachTags(*uuid.UUID, *uuid.UUID, []uuid.UUID) error
+
+	// DetachTags will detach tags from a task owned by a user.
+	DetachTags(*uuid.UUID, *uuid.UUID, []uuid.UUID) error
+
+	// GetLists will get the lists of a user and the lists shared with them, archived lists are included only if asked for.
+	GetLists(*uuid.UUID, bool) ([]List, error)
+
+	// GetList will get a list owned by a user.
+	GetList(*uuid.UUID, *uuid.UUID) (*List, error)
+
+	// GetInbox will get the inbox list of a user.
+	GetInbox(*uuid.UUID) (*List, error)
+
+	// AddList will add a new list to a user.
+	AddList(*List, *uuid.UUID) error
+
+	// AddInbox will add the inbox list of a user unless they already have one.
+	AddInbox(*List, *uuid.UUID) error
+
+	// UpdateList will update a list owned by a user.
+	UpdateList(*List, *uuid.UUID) (*List, error)
+
+	// GetListTasks will get the tasks of a list owned by a user including the tasks in the trash.
+	GetListTasks(*uuid.UUID, *uuid.UUID) ([]Task, error)
+
+	// DeleteList will move the tasks of a list owned by a user to another list and delete it.
+	DeleteList(*uuid.UUID, *uuid.UUID, *uuid.UUID) error
+
+	// MoveToList will move a task owned by a user and all its descendants to a list.
+	MoveToList(*uuid.UUID, *uuid.UUID, *uuid.UUID) error
+
+	// GetTaskAccess will get the owner of a task and the highest role a user has on it.
+	GetTaskAccess(*uuid.UUID, *uuid.UUID) (*uuid.UUID, Role, error)
+
+	// GetListAccess will get the owner of a list and the highest role a user has on it.
+	GetListAccess(*uuid.UUID, *uuid.UUID) (*uuid.UUID, Role, error)
+
+	// GetUserIdByEmail will get the id of the registered user with an email.
+	GetUserIdByEmail(string) (*uuid.UUID, error)
+
+	// GetShares will get the shares of a task or a list.
+	GetShares(*ShareTarget) ([]Share, error)
+
+	// GetShare will get a share by its id.
+	GetShare(*uuid.UUID) (*Share, error)
+
+	// CheckShare will check that a user has no share on a task or a list yet.
+	CheckShare(*ShareTarget, *uuid.UUID) (bool, error)
+
+	// AddShare will add a new share.
+	AddShare(*Share) error
+
+	// UpdateShare will change the role of a share.
+	UpdateShare(*uuid.UUID, Role) error
+
+	// DeleteShare will revoke a share.
+	DeleteShare(*uuid.UUID) error
+
+	// CopyShares will grant the shares of a task on another task.
+	CopyShares(*uuid.UUID, *uuid.UUID) error
+
+	// GetPriorities will get every task priority.
+	GetPriorities() ([]Priority, error)
+
+	// GetFeedUser will get the user of a calendar feed by the hash of its token.
+	GetFeedUser(string) (*uuid.UUID, error)
+
+	// SetFeedToken will set the hash of the calendar feed token of a user.
+	SetFeedToken(*uuid.UUID, string) error
+
+	// DeleteFeedToken will delete the calendar feed token of a user.
+	DeleteFeedToken(*uuid.UUID) error
+
+	// GetTaskFamily will get a task, its descendants and the occurrences of its series including the tasks in the trash.
+	GetTaskFamily(*uuid.UUID, *uuid.UUID) ([]Task, error)
+
+	// AddHistory will add history entries.
+	AddHistory([]HistoryEntry) error
+
+	// GetTaskHistory will get a page of the history of a task.
+	GetTaskHistory(*uuid.UUID, *HistoryCursor, int) ([]HistoryEntry, error)
+
+	// GetActivity will get a page of the history of the tasks of a user.
+	GetActivity(*uuid.UUID, *HistoryCursor, int) ([]HistoryEntry, error)
+
+	// AddUndo will add a change that can be reverted until it expires.
+	AddUndo(*Undo) error
+
+	// TakeUndo will delete and return a change made by a user that has not expired.
+	TakeUndo(*uuid.UUID, *uuid.UUID, *time.Time) (*Undo, error)
+
+	// DeleteExpiredUndos will delete the changes that expired before a date.
+	DeleteExpiredUndos(*time.Time) (int64, error)
+
+	// ReplaceTask will write every stored column and the tags of a task.
+	ReplaceTask(*Task, *uuid.UUID) error
+
+	// TrashTasks will move tasks owned by a user to the trash.
+	TrashTasks([]uuid.UUID, *uuid.UUID, *time.Time) error
+
+	// GetLastRank will get the last rank of the tasks of a user.
+	GetLastRank(*uuid.UUID) (string, error)
+
+	// GetAdjacentRank will get the rank of the task of a user right before or after a rank, ignoring a task.
+	GetAdjacentRank(*uuid.UUID, string, Placement, *uuid.UUID) (string, error)
+
+	// SetRank will change the rank of a task.
+	SetRank(*uuid.UUID, *uuid.UUID, string) (*Task, error)
+
+	// GetLongRankUsers will get the users having a task with a rank longer than a length.
+	GetLongRankUsers(int) ([]uuid.UUID, error)
+
+	// GetRankOrder will lock the tasks of a user and get their ids in the manual order.
+	GetRankOrder(*uuid.UUID) ([]uuid.UUID, error)
+
+	// SetRanks will set the ranks of tasks without changing their versions.
+	SetRanks(*uuid.UUID, []uuid.UUID, []string) error
+
+	// GetDependencies will get every edge of the dependency graph of the tasks of a user.
+	GetDependencies(*uuid.UUID) ([]Dependency, error)
+
+	// AddDependency will add an edge to the dependency graph.
+	AddDependency(*Dependency) error
+
+	// RemoveDependency will remove an edge from the dependency graph.
+	RemoveDependency(*Dependency) error
+
+	// GetBlockers will get the tasks blocking a task owned by a user.
+	GetBlockers(*uuid.UUID, *uuid.UUID) ([]Task, error)
+
+	// GetDueLoads will sum the estimates of the open tasks of a user due before a time by day in a time zone.
+	GetDueLoads(*uuid.UUID, time.Time, time.Time, *time.Location) ([]DueLoad, error)
+
+	// Transaction will call a function with a repository running every query in one transaction,
+	// which is committed only if the function returns nil.
+	Transaction(func(Repository) error) error
 
-	// DeleteTask will delete a task with a specific id.
-	DeleteTask(*uuid.UUID) error
+	// LockUser will lock a user until the transaction ends, it must be called inside Transaction.
+	LockUser(*uuid.UUID) error
 }
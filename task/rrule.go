@@ -0,0 +1,385 @@
+package task
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency is the FREQ part of a recurrence rule.
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxPeriods is how many periods are searched for the next occurrence before the rule is considered finished.
+// It stops rules that can never match, like the 30th of February, from looping forever.
+const maxPeriods = 1000
+
+// weekdays maps the RFC 5545 weekday names.
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum is a BYDAY value like MO or -1FR.
+// An Ordinal of 0 matches every such weekday in the period.
+type WeekdayNum struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// Rule is a parsed RFC 5545 recurrence rule.
+// Only the DAILY, WEEKLY, MONTHLY and YEARLY frequencies with
+// the INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST parts are supported.
+type Rule struct {
+	Frequency  Frequency
+	Interval   int
+	Count      int64
+	Until      *time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+	text       string
+}
+
+// String will return the rule in RRULE syntax without the RRULE: prefix.
+func (r *Rule) String() string {
+	return r.text
+}
+
+// parseUntil will parse the UNTIL value in its date or date-time form.
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		until, err := time.Parse(layout, value)
+		if err == nil {
+			if layout == "20060102" {
+				until = until.Add(24*time.Hour - time.Nanosecond)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, ErrInvalidRecurrence
+}
+
+// parseInts will parse a comma separated list of integers between min and max, excluding zero.
+func parseInts(value string, min int, max int) ([]int, error) {
+	var result []int
+	for _, part := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(part)
+		if err != nil || number == 0 || number < min || number > max {
+			return nil, ErrInvalidRecurrence
+		}
+		result = append(result, number)
+	}
+	return result, nil
+}
+
+// parseWeekdayNum will parse a single BYDAY value.
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	if len(value) < 2 {
+		return WeekdayNum{}, ErrInvalidRecurrence
+	}
+
+	weekday, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, ErrInvalidRecurrence
+	}
+
+	ordinal := 0
+	if prefix := value[:len(value)-2]; prefix != "" {
+		var err error
+		ordinal, err = strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -53 || ordinal > 53 {
+			return WeekdayNum{}, ErrInvalidRecurrence
+		}
+	}
+
+	return WeekdayNum{Ordinal: ordinal, Weekday: weekday}, nil
+}
+
+// ParseRule will parse a recurrence rule in RRULE syntax. The RRULE: prefix is optional.
+func ParseRule(text string) (*Rule, error) {
+	text = strings.ToUpper(strings.TrimSpace(text))
+	text = strings.TrimPrefix(text, "RRULE:")
+
+	rule := &Rule{Interval: 1, WeekStart: time.Monday, text: text}
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(text, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" || seen[key] {
+			return nil, ErrInvalidRecurrence
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Frequency = Frequency(value)
+			switch rule.Frequency {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+			default:
+				return nil, ErrInvalidRecurrence
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+			if err != nil || rule.Interval < 1 {
+				return nil, ErrInvalidRecurrence
+			}
+		case "COUNT":
+			rule.Count, err = strconv.ParseInt(value, 10, 64)
+			if err != nil || rule.Count < 1 {
+				return nil, ErrInvalidRecurrence
+			}
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekdayNum, err := parseWeekdayNum(day)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, weekdayNum)
+			}
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseInts(value, -31, 31)
+			if err != nil {
+				return nil, err
+			}
+		case "BYMONTH":
+			months, err := parseInts(value, 1, 12)
+			if err != nil {
+				return nil, err
+			}
+			for _, month := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		case "WKST":
+			weekStart, ok := weekdays[value]
+			if !ok {
+				return nil, ErrInvalidRecurrence
+			}
+			rule.WeekStart = weekStart
+		default:
+			return nil, ErrInvalidRecurrence
+		}
+	}
+
+	if rule.Frequency == "" || (seen["COUNT"] && seen["UNTIL"]) {
+		return nil, ErrInvalidRecurrence
+	}
+
+	if rule.Frequency == FrequencyWeekly && len(rule.ByMonthDay) > 0 {
+		return nil, ErrInvalidRecurrence
+	}
+
+	for _, day := range rule.ByDay {
+		if day.Ordinal == 0 {
+			continue
+		}
+		if rule.Frequency == FrequencyDaily || rule.Frequency == FrequencyWeekly {
+			return nil, ErrInvalidRecurrence
+		}
+		if rule.Frequency == FrequencyMonthly && (day.Ordinal < -5 || day.Ordinal > 5) {
+			return nil, ErrInvalidRecurrence
+		}
+	}
+
+	return rule, nil
+}
+
+// daysIn will return the number of days in a month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekdaysInRange will return the dates in [first, last] that match a BYDAY value.
+func weekdaysInRange(first time.Time, last time.Time, day WeekdayNum) []time.Time {
+	var matches []time.Time
+	for date := first; !date.After(last); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() == day.Weekday {
+			matches = append(matches, date)
+		}
+	}
+
+	switch {
+	case day.Ordinal > 0 && day.Ordinal <= len(matches):
+		return matches[day.Ordinal-1 : day.Ordinal]
+	case day.Ordinal < 0 && -day.Ordinal <= len(matches):
+		index := len(matches) + day.Ordinal
+		return matches[index : index+1]
+	case day.Ordinal == 0:
+		return matches
+	}
+	return nil
+}
+
+// monthDays will return the dates of a month that match the BYMONTHDAY and BYDAY parts.
+// The fallback day is used when neither part is set.
+func (r *Rule) monthDays(year int, month time.Month, fallback int) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(year, month, daysIn(year, month), 0, 0, 0, 0, time.UTC)
+
+	var byMonthDay []time.Time
+	for _, day := range r.ByMonthDay {
+		if day < 0 {
+			day = daysIn(year, month) + day + 1
+		}
+		if day >= 1 && day <= daysIn(year, month) {
+			byMonthDay = append(byMonthDay, time.Date(year, month, day, 0, 0, 0, 0, time.UTC))
+		}
+	}
+
+	var byDay []time.Time
+	for _, day := range r.ByDay {
+		byDay = append(byDay, weekdaysInRange(first, last, day)...)
+	}
+
+	switch {
+	case len(r.ByMonthDay) > 0 && len(r.ByDay) > 0:
+		var both []time.Time
+		for _, date := range byMonthDay {
+			if slices.ContainsFunc(byDay, date.Equal) {
+				both = append(both, date)
+			}
+		}
+		return both
+	case len(r.ByMonthDay) > 0:
+		return byMonthDay
+	case len(r.ByDay) > 0:
+		return byDay
+	case fallback <= daysIn(year, month):
+		return []time.Time{time.Date(year, month, fallback, 0, 0, 0, 0, time.UTC)}
+	}
+	return nil
+}
+
+// matchesDay will check if a date matches the BYMONTHDAY and BYDAY parts of a daily rule.
+func (r *Rule) matchesDay(date time.Time) bool {
+	if len(r.ByMonthDay) > 0 && !slices.ContainsFunc(r.ByMonthDay, func(day int) bool {
+		if day < 0 {
+			day = daysIn(date.Year(), date.Month()) + day + 1
+		}
+		return day == date.Day()
+	}) {
+		return false
+	}
+
+	if len(r.ByDay) > 0 && !slices.ContainsFunc(r.ByDay, func(day WeekdayNum) bool {
+		return day.Weekday == date.Weekday()
+	}) {
+		return false
+	}
+	return true
+}
+
+// candidates will return the dates in the period of the rule that starts at a date.
+// The start is used for the parts of the date the rule does not set.
+func (r *Rule) candidates(period time.Time, start time.Time) []time.Time {
+	var dates []time.Time
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		dates = []time.Time{period}
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+			dates = []time.Time{period.AddDate(0, 0, offset)}
+		}
+		for _, day := range r.ByDay {
+			offset := (int(day.Weekday) - int(r.WeekStart) + 7) % 7
+			dates = append(dates, period.AddDate(0, 0, offset))
+		}
+	case FrequencyMonthly:
+		dates = r.monthDays(period.Year(), period.Month(), start.Day())
+	case FrequencyYearly:
+		switch {
+		case len(r.ByMonth) > 0:
+			for _, month := range r.ByMonth {
+				dates = append(dates, r.monthDays(period.Year(), month, start.Day())...)
+			}
+		case len(r.ByMonthDay) > 0:
+			dates = r.monthDays(period.Year(), start.Month(), start.Day())
+		case len(r.ByDay) > 0:
+			first := time.Date(period.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+			last := time.Date(period.Year(), time.December, 31, 0, 0, 0, 0, time.UTC)
+			for _, day := range r.ByDay {
+				dates = append(dates, weekdaysInRange(first, last, day)...)
+			}
+		default:
+			dates = r.monthDays(period.Year(), start.Month(), start.Day())
+		}
+	}
+
+	var filtered []time.Time
+	for _, date := range dates {
+		if len(r.ByMonth) > 0 && !slices.Contains(r.ByMonth, date.Month()) {
+			continue
+		}
+		if r.Frequency == FrequencyDaily && !r.matchesDay(date) {
+			continue
+		}
+
+		filtered = append(filtered, time.Date(date.Year(), date.Month(), date.Day(),
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location()))
+	}
+
+	slices.SortFunc(filtered, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(filtered, time.Time.Equal)
+}
+
+// period will return the first day of the period that is the given number of periods after the one containing start.
+func (r *Rule) period(start time.Time, periods int) time.Time {
+	day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		return day.AddDate(0, 0, periods*r.Interval)
+	case FrequencyWeekly:
+		offset := (int(day.Weekday()) - int(r.WeekStart) + 7) % 7
+		return day.AddDate(0, 0, periods*r.Interval*7-offset)
+	case FrequencyMonthly:
+		return time.Date(day.Year(), day.Month()+time.Month(periods*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(day.Year()+periods*r.Interval, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// Next will return the occurrence that follows an occurrence of the rule.
+// The index is the 1-based position of the given occurrence in the series and is used for COUNT.
+// It returns false when the series has no more occurrences.
+func (r *Rule) Next(occurrence time.Time, index int64) (time.Time, bool) {
+	if r.Count > 0 && index >= r.Count {
+		return time.Time{}, false
+	}
+
+	for periods := 0; periods < maxPeriods; periods++ {
+		for _, candidate := range r.candidates(r.period(occurrence, periods), occurrence) {
+			if !candidate.After(occurrence) {
+				continue
+			}
+			if r.Until != nil && candidate.After(*r.Until) {
+				return time.Time{}, false
+			}
+			return candidate, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,157 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseRule(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Rule
+	}{
+		{
+			name: "prefix and lower case",
+			text: "rrule:freq=daily",
+			want: Rule{Frequency: FrequencyDaily, Interval: 1, WeekStart: time.Monday},
+		},
+		{
+			name: "weekly with days",
+			text: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;WKST=SU",
+			want: Rule{Frequency: FrequencyWeekly, Interval: 2, WeekStart: time.Sunday,
+				ByDay: []WeekdayNum{{Weekday: time.Monday}, {Weekday: time.Friday}}},
+		},
+		{
+			name: "monthly with ordinal days",
+			text: "FREQ=MONTHLY;BYDAY=-1FR,2TU;COUNT=5",
+			want: Rule{Frequency: FrequencyMonthly, Interval: 1, Count: 5, WeekStart: time.Monday,
+				ByDay: []WeekdayNum{{Ordinal: -1, Weekday: time.Friday}, {Ordinal: 2, Weekday: time.Tuesday}}},
+		},
+		{
+			name: "yearly with months and month days",
+			text: "FREQ=YEARLY;BYMONTH=2,8;BYMONTHDAY=-1",
+			want: Rule{Frequency: FrequencyYearly, Interval: 1, WeekStart: time.Monday,
+				ByMonth: []time.Month{time.February, time.August}, ByMonthDay: []int{-1}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := ParseRule(test.text)
+			if err != nil {
+				t.Fatalf("ParseRule(%q) returned %v", test.text, err)
+			}
+			if rule.Frequency != test.want.Frequency || rule.Interval != test.want.Interval || rule.Count != test.want.Count ||
+				rule.WeekStart != test.want.WeekStart || !slices.Equal(rule.ByDay, test.want.ByDay) ||
+				!slices.Equal(rule.ByMonthDay, test.want.ByMonthDay) || !slices.Equal(rule.ByMonth, test.want.ByMonth) {
+				t.Errorf("ParseRule(%q) = %+v, want %+v", test.text, *rule, test.want)
+			}
+		})
+	}
+}
+
+func TestParseRuleUntil(t *testing.T) {
+	tests := []struct {
+		text string
+		want time.Time
+	}{
+		{"FREQ=DAILY;UNTIL=20260110T120000Z", time.Date(2026, time.January, 10, 12, 0, 0, 0, time.UTC)},
+		{"FREQ=DAILY;UNTIL=20260110", time.Date(2026, time.January, 10, 23, 59, 59, 999999999, time.UTC)},
+	}
+
+	for _, test := range tests {
+		rule, err := ParseRule(test.text)
+		if err != nil {
+			t.Fatalf("ParseRule(%q) returned %v", test.text, err)
+		}
+		if rule.Until == nil || !rule.Until.Equal(test.want) {
+			t.Errorf("ParseRule(%q).Until = %v, want %v", test.text, rule.Until, test.want)
+		}
+	}
+}
+
+func TestParseRuleInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20260101",
+		"FREQ=DAILY;UNTIL=2026-01-01",
+		"FREQ=DAILY;BYSETPOS=1",
+		"FREQ=DAILY;BYDAY=XX",
+		"FREQ=DAILY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYDAY=-1FR",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=YEARLY;BYMONTH=13",
+		"FREQ=WEEKLY;WKST=XX",
+		"FREQ=DAILY;COUNT=",
+	}
+
+	for _, text := range tests {
+		_, err := ParseRule(text)
+		if !errors.Is(err, ErrInvalidRecurrence) {
+			t.Errorf("ParseRule(%q) returned %v, want %v", text, err, ErrInvalidRecurrence)
+		}
+	}
+}
+
+func TestRuleNext(t *testing.T) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 30, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		rule       string
+		occurrence time.Time
+		index      int64
+		want       time.Time
+		ok         bool
+	}{
+		{"daily", "FREQ=DAILY", at(2026, time.January, 1), 1, at(2026, time.January, 2), true},
+		{"daily interval", "FREQ=DAILY;INTERVAL=3", at(2026, time.January, 30), 1, at(2026, time.February, 2), true},
+		{"daily on weekdays", "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR", at(2026, time.January, 9), 1, at(2026, time.January, 12), true},
+		{"weekly on the same day", "FREQ=WEEKLY", at(2026, time.January, 7), 1, at(2026, time.January, 14), true},
+		{"weekly within the week", "FREQ=WEEKLY;BYDAY=MO,WE,FR", at(2026, time.January, 5), 1, at(2026, time.January, 7), true},
+		{"weekly into the next week", "FREQ=WEEKLY;BYDAY=MO,WE,FR", at(2026, time.January, 9), 1, at(2026, time.January, 12), true},
+		{"weekly interval", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", at(2026, time.January, 5), 1, at(2026, time.January, 19), true},
+		{"monthly skips short months", "FREQ=MONTHLY", at(2026, time.January, 31), 1, at(2026, time.March, 31), true},
+		{"monthly on the last day", "FREQ=MONTHLY;BYMONTHDAY=-1", at(2026, time.January, 31), 1, at(2026, time.February, 28), true},
+		{"monthly on the last day of a leap year", "FREQ=MONTHLY;BYMONTHDAY=-1", at(2028, time.January, 31), 1, at(2028, time.February, 29), true},
+		{"monthly on the last friday", "FREQ=MONTHLY;BYDAY=-1FR", at(2026, time.January, 30), 1, at(2026, time.February, 27), true},
+		{"monthly on the second tuesday", "FREQ=MONTHLY;BYDAY=2TU", at(2026, time.January, 13), 1, at(2026, time.February, 10), true},
+		{"monthly on friday the 13th", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", at(2026, time.February, 13), 1, at(2026, time.March, 13), true},
+		{"yearly", "FREQ=YEARLY", at(2026, time.March, 15), 1, at(2027, time.March, 15), true},
+		{"yearly on leap days", "FREQ=YEARLY", at(2024, time.February, 29), 1, at(2028, time.February, 29), true},
+		{"yearly in months", "FREQ=YEARLY;BYMONTH=1,7", at(2026, time.January, 10), 1, at(2026, time.July, 10), true},
+		{"count not reached", "FREQ=DAILY;COUNT=3", at(2026, time.January, 1), 2, at(2026, time.January, 2), true},
+		{"count reached", "FREQ=DAILY;COUNT=3", at(2026, time.January, 1), 3, time.Time{}, false},
+		{"until date includes the day", "FREQ=DAILY;UNTIL=20260110", at(2026, time.January, 9), 1, at(2026, time.January, 10), true},
+		{"until date passed", "FREQ=DAILY;UNTIL=20260110", at(2026, time.January, 10), 1, time.Time{}, false},
+		{"until time passed", "FREQ=DAILY;UNTIL=20260110T090000Z", at(2026, time.January, 9), 1, time.Time{}, false},
+		{"never matches", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", at(2026, time.January, 1), 1, time.Time{}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, err := ParseRule(test.rule)
+			if err != nil {
+				t.Fatalf("ParseRule(%q) returned %v", test.rule, err)
+			}
+
+			next, ok := rule.Next(test.occurrence, test.index)
+			if ok != test.ok || !next.Equal(test.want) {
+				t.Errorf("Next(%v, %d) = %v, %t, want %v, %t", test.occurrence, test.index, next, ok, test.want, test.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package task
+
+import (
+	"strings"
+	"unicode"
+)
+
+// searchConfig is the text search configuration used to parse task names and descriptions.
+const searchConfig = "english"
+
+// searchDocument is the weighted text search document of a task, names rank above descriptions.
+// It matches the expression of the GIN index added by migrations/0010_task_search.sql so searches can use it.
+const searchDocument = "(setweight(to_tsvector('" + searchConfig + "', name), 'A') || setweight(to_tsvector('" + searchConfig + "', description), 'B'))"
+
+// Highlight marks the matched words in the snippets of a search result.
+// The snippets are not HTML escaped, clients must escape them before replacing the marks.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// Highlights are the snippets of a task with the matched words marked.
+type Highlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// SearchResult is a task matching a search with its rank and highlights.
+type SearchResult struct {
+	Task       Task       `json:"task"`
+	Rank       float64    `json:"rank"`
+	Highlights Highlights `json:"highlights"`
+}
+
+// parseSearch will turn the text typed by a user into a tsquery matching every word as a prefix.
+// Punctuation is dropped so the text can never produce an invalid tsquery.
+// It returns false if the text has no words.
+func parseSearch(text string) (string, bool) {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "", false
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = word + ":*"
+	}
+	return strings.Join(terms, " & "), true
+}
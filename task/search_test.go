@@ -0,0 +1,27 @@
+package task
+
+import "testing"
+
+func TestParseSearch(t *testing.T) {
+	tests := []struct {
+		text  string
+		want  string
+		found bool
+	}{
+		{"report", "report:*", true},
+		{"  quarterly   report ", "quarterly:* & report:*", true},
+		{"q3-report", "q3:* & report:*", true},
+		{"it's & | ! (bank):*", "it:* & s:* & bank:*", true},
+		{"Überweisung 2026", "Überweisung:* & 2026:*", true},
+		{"", "", false},
+		{"   ", "", false},
+		{"&|!():*'", "", false},
+	}
+
+	for _, test := range tests {
+		query, found := parseSearch(test.text)
+		if query != test.want || found != test.found {
+			t.Errorf("parseSearch(%q) = %q, %t, want %q, %t", test.text, query, found, test.want, test.found)
+		}
+	}
+}
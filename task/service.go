@@ -2,8 +2,10 @@ package task
 
 import (
 	"database/sql"
+	"errors"
 	"log"
 	"task-server/middleware"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,39 +14,124 @@ import (
 type ServiceImp struct {
 	Repository    Repository
 	Authenticator middleware.Authenticator
+	// MaxDepth is how many levels tasks can be nested, top level tasks are the first level.
+	MaxDepth int
+	// UndoWindow is how long a change can be reverted with its undo token, no tokens are created if it is zero.
+	UndoWindow time.Duration
+	// Capacity is the effort a user can take on in a day, days over it are flagged in the workload.
+	Capacity Capacity
 }
 
-// GetTasks will return a slice of all tasks that belongs to a user.
-func (s *ServiceImp) GetTasks(tokenString *string) ([]Task, error) {
+// GetTasks will return a page of the tasks that belongs to or are shared with a user and match the filter.
+func (s *ServiceImp) GetTasks(tokenString *string, filter *Filter) (*Page, error) {
 	id, err := s.Authenticator.CheckAccessToken(tokenString)
 	if err != nil {
 		log.Printf("Error in task-ServiceImp-GetTasks: %v", err)
 		return nil, ErrInvalidToken
 	}
 
-	tasks, err := s.Repository.GetTasks(id)
+	if filter.Limit < 1 || filter.Limit > MaxLimit {
+		return nil, ErrInvalidFilter
+	}
+
+	tasks, err := s.Repository.GetTasks(id, filter)
 	if err != nil {
 		log.Printf("Error in task-ServiceImp-GetTasks: %v", err)
 		return nil, err
 	}
-	return tasks, nil
+
+	page := &Page{Tasks: tasks}
+	if len(tasks) > filter.Limit {
+		page.Tasks = tasks[:filter.Limit]
+		page.Next, err = newCursor(filter, &page.Tasks[filter.Limit-1]).Encode()
+		if err != nil {
+			log.Printf("Error in task-ServiceImp-GetTasks: %v", err)
+			return nil, err
+		}
+	}
+
+	return page, nil
 }
 
-// AddTask will add a new task to a user.
-func (s *ServiceImp) AddTask(tokenString *string, newTask *NewTask) (*Task, error) {
+// SearchTasks will return the tasks of a user matching the filter and containing words starting with the words of the text.
+// The results are ordered by rank, the sort and cursor of the filter are not used.
+func (s *ServiceImp) SearchTasks(tokenString *string, text string, filter *Filter) ([]SearchResult, error) {
 	id, err := s.Authenticator.CheckAccessToken(tokenString)
 	if err != nil {
-		log.Printf("Error in task-ServiceImp-AddTask: %v", err)
+		log.Printf("Error in task-ServiceImp-SearchTasks: %v", err)
 		return nil, ErrInvalidToken
 	}
 
+	search, ok := parseSearch(text)
+	if !ok || filter.After != nil || filter.Limit < 1 || filter.Limit > MaxLimit {
+		return nil, ErrInvalidFilter
+	}
+
+	results, err := s.Repository.SearchTasks(id, filter, search)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-SearchTasks: %v", err)
+		return nil, err
+	}
+	return results, nil
+}
+
+// newTaskList will return the list of a new task: the list of its parent, the requested list or the inbox of the user.
+func (s *ServiceImp) newTaskList(newTask *NewTask, userId *uuid.UUID) (*uuid.UUID, error) {
+	if newTask.ParentId.Valid {
+		parent, err := s.Repository.GetTask(&newTask.ParentId.UUID, userId)
+		if errors.Is(err, ErrTaskNotFound) {
+			return nil, ErrInvalidParent
+		} else if err != nil {
+			return nil, err
+		}
+		return &parent.ListId, nil
+	}
+
+	if newTask.ListId.Valid {
+		_, err := s.Repository.GetList(&newTask.ListId.UUID, userId)
+		if errors.Is(err, ErrListNotFound) {
+			return nil, ErrInvalidList
+		} else if err != nil {
+			return nil, err
+		}
+		return &newTask.ListId.UUID, nil
+	}
+
+	inbox, err := s.inbox(userId)
+	if err != nil {
+		return nil, err
+	}
+	return &inbox.Id, nil
+}
+
+// AddTask will add a new task to a user and return the token that removes it again.
+func (s *ServiceImp) AddTask(tokenString *string, newTask *NewTask) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddTask: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
 	ok, err := s.Repository.CheckPriority(&newTask.Priority)
 	if err != nil {
 		log.Printf("Error in task-ServiceImp-AddTask: %v", err)
-		return nil, err
+		return nil, "", err
 	}
 	if !ok {
-		return nil, ErrInvalidPriority
+		return nil, "", ErrInvalidPriority
+	}
+
+	if !newTask.Estimate.valid() {
+		return nil, "", ErrInvalidEstimate
+	}
+
+	var recurrence *Recurrence
+	if newTask.Recurrence != "" {
+		rule, err := ParseRule(newTask.Recurrence)
+		if err != nil {
+			return nil, "", err
+		}
+		recurrence = newRecurrence(rule, newTask.DueDate)
 	}
 
 	task := &Task{
@@ -55,62 +142,599 @@ func (s *ServiceImp) AddTask(tokenString *string, newTask *NewTask) (*Task, erro
 		DueDate:       newTask.DueDate,
 		DateDeleted:   NullTime{sql.NullTime{Valid: false}},
 		DateCompleted: NullTime{sql.NullTime{Valid: false}},
+		Version:       1,
+		Recurrence:    recurrence,
+		ParentId:      newTask.ParentId,
+		Estimate:      newTask.Estimate,
+		Tags:          []Tag{},
 	}
-	err = s.Repository.AddTask(task, id)
-	if err != nil {
+	undoToken, err := s.recordChanges(id, id, &task.Id, func(service *ServiceImp) error {
+		// the user is locked so concurrent adds do not read the same last rank
+		// and a concurrent move can not nest the parent deeper after its depth was checked
+		err := service.Repository.LockUser(id)
+		if err != nil {
+			return err
+		}
+
+		if newTask.ParentId.Valid {
+			depth, err := service.Repository.GetDepth(&newTask.ParentId.UUID, id)
+			if errors.Is(err, ErrTaskNotFound) {
+				return ErrInvalidParent
+			} else if err != nil {
+				return err
+			}
+
+			if depth+1 > service.MaxDepth {
+				return ErrMaxDepthExceeded
+			}
+		}
+
+		listId, err := service.newTaskList(newTask, id)
+		if err != nil {
+			return err
+		}
+		task.ListId = *listId
+
+		lastRank, err := service.Repository.GetLastRank(id)
+		if err != nil {
+			return err
+		}
+
+		task.Rank = rankBetween(lastRank, "")
+		return service.Repository.AddTask(task, id)
+	})
+	if errors.Is(err, ErrInvalidParent) || errors.Is(err, ErrMaxDepthExceeded) || errors.Is(err, ErrInvalidList) {
+		return nil, "", err
+	} else if err != nil {
 		log.Printf("Error in task-ServiceImp-AddTask: %v", err)
-		return nil, err
+		return nil, "", err
 	}
 
-	return task, nil
+	return task, undoToken, nil
 }
 
-// UpdateTask will update an existing task information.
-func (s *ServiceImp) UpdateTask(stringToken *string, task *Task) (*Task, error) {
-	_, err := s.Authenticator.CheckAccessToken(stringToken)
+// UpdateTask will update an existing task information and return the token that reverts the update.
+// If version is not nil the task is updated only if its version matches.
+// Completing a task with open blockers is rejected with ErrTaskBlocked, only CompleteTask can force it.
+// Completing an occurrence of a recurring task will add the next occurrence of its series.
+func (s *ServiceImp) UpdateTask(stringToken *string, task *Task, version *int64) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(stringToken)
 	if err != nil {
 		log.Printf("Error in task-ServiceImp-UpdateTask: %v", err)
-		return nil, ErrInvalidToken
+		return nil, "", ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, &task.Id, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateTask: %v", err)
+		return nil, "", err
 	}
 
 	ok, err := s.Repository.CheckPriority(&task.Priority)
 	if err != nil {
 		log.Printf("Error in task-ServiceImp-UpdateTask: %v", err)
+		return nil, "", err
 	}
 
 	if !ok {
-		return nil, ErrInvalidPriority
+		return nil, "", ErrInvalidPriority
 	}
 
-	err = s.Repository.UpdateTask(task)
-	if err != nil {
+	if !task.Estimate.valid() {
+		return nil, "", ErrInvalidEstimate
+	}
+
+	var updatedTask *Task
+	undoToken, err := s.recordChanges(id, ownerId, &task.Id, func(service *ServiceImp) error {
+		current, err := service.Repository.GetTask(&task.Id, ownerId)
+		if err != nil {
+			return err
+		}
+
+		// only completing the task is blocked, a task that is already completed can still be edited
+		if task.DateCompleted.Valid && !current.DateCompleted.Valid {
+			err := service.checkUnblocked(&task.Id, ownerId)
+			if err != nil {
+				return err
+			}
+		}
+
+		updatedTask, err = service.Repository.UpdateTask(task, ownerId, version)
+		if err != nil {
+			return err
+		}
+		return service.continueSeries(updatedTask, current.DateCompleted.Valid, ownerId)
+	})
+	if errors.Is(err, ErrTaskBlocked) {
+		return nil, "", err
+	} else if err != nil {
 		log.Printf("Error in task-ServiceImp-UpdateTask: %v", err)
-		return nil, err
+		return nil, "", err
 	}
-	return task, nil
+	return updatedTask, undoToken, nil
 }
 
-// DeleteTask will delete a existing task.
-func (s *ServiceImp) DeleteTask(tokenString *string, uuid *uuid.UUID) error {
-	_, err := s.Authenticator.CheckAccessToken(tokenString)
+// DeleteTask will move an existing task to the trash and return the token that restores it.
+// If version is not nil the task is deleted only if its version matches.
+// If cascade is true the subtasks are moved to the trash too, otherwise they are moved to the parent of the task.
+func (s *ServiceImp) DeleteTask(tokenString *string, taskId *uuid.UUID, version *int64, cascade bool) (string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
 	if err != nil {
 		log.Printf("Error in task-ServiceImp-DeleteTask: %v", err)
-		return ErrInvalidToken
+		return "", ErrInvalidToken
 	}
 
-	err = s.Repository.DeleteTask(uuid)
+	ownerId, err := s.authorize(id, taskId, RoleAdmin)
 	if err != nil {
 		log.Printf("Error in task-ServiceImp-DeleteTask: %v", err)
+		return "", err
+	}
+
+	dateDeleted := time.Now().UTC()
+	undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+		err := service.Repository.DeleteTask(taskId, ownerId, &dateDeleted, version)
+		if err != nil {
+			return err
+		}
+
+		if cascade {
+			return service.Repository.DeleteDescendants(taskId, ownerId, &dateDeleted)
+		}
+		return service.Repository.ReparentChildren(taskId, ownerId)
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteTask: %v", err)
+		return "", err
+	}
+
+	return undoToken, nil
+}
+
+// addNextOccurrence will add the occurrence that follows a recurring task right after it in the manual order.
+// It returns nil if the series has no more occurrences. It must run in a transaction, the rank is read under a lock of the user.
+func (s *ServiceImp) addNextOccurrence(task *Task, userId *uuid.UUID) (*Task, error) {
+	rule, err := ParseRule(task.Recurrence.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	date, ok := rule.Next(task.Recurrence.Date, task.Recurrence.Index)
+	if !ok {
+		return nil, nil
+	}
+
+	err = s.Repository.LockUser(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	nextRank, err := s.Repository.GetAdjacentRank(userId, task.Rank, PlaceAfter, &task.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	next := &Task{
+		Id:            uuid.New(),
+		Name:          task.Name,
+		Description:   task.Description,
+		Priority:      task.Priority,
+		DueDate:       date,
+		DateDeleted:   NullTime{sql.NullTime{Valid: false}},
+		DateCompleted: NullTime{sql.NullTime{Valid: false}},
+		Version:       1,
+		ParentId:      task.ParentId,
+		ListId:        task.ListId,
+		Rank:          rankBetween(task.Rank, nextRank),
+		Estimate:      task.Estimate,
+		Tags:          []Tag{},
+		Recurrence: &Recurrence{
+			Rule:     task.Recurrence.Rule,
+			SeriesId: task.Recurrence.SeriesId,
+			Date:     date,
+			Index:    task.Recurrence.Index + 1,
+		},
+	}
+	err = s.Repository.AddTask(next, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Repository.CopyShares(&task.Id, &next.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(task.Tags) > 0 {
+		tagIds := make([]uuid.UUID, len(task.Tags))
+		for i, tag := range task.Tags {
+			tagIds[i] = tag.Id
+		}
+
+		err = s.Repository.AttachTags(&next.Id, userId, tagIds)
+		if err != nil {
+			return nil, err
+		}
+		next.Tags = task.Tags
+	}
+	return next, nil
+}
+
+// continueSeries will add the next occurrence of a recurring task that a change has just completed.
+// wasCompleted is true if the task was already completed before the change, then the series has already moved on.
+// Every way of completing a task goes through it so a series never stops because of how its occurrence was completed.
+func (s *ServiceImp) continueSeries(task *Task, wasCompleted bool, ownerId *uuid.UUID) error {
+	if wasCompleted || !task.DateCompleted.Valid || task.Recurrence == nil {
+		return nil
+	}
+	_, err := s.addNextOccurrence(task, ownerId)
+	return err
+}
+
+// CompleteTask will set the completion date of a task to the current server time and return the token that reverts it.
+// Completing an occurrence of a recurring task will add the next occurrence of its series.
+// Completing an already completed task will keep its original completion date.
+// If cascade is true all subtasks are completed too.
+// A task with open blockers is only completed if force is true.
+func (s *ServiceImp) CompleteTask(tokenString *string, taskId *uuid.UUID, cascade bool, force bool) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-CompleteTask: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-CompleteTask: %v", err)
+		return nil, "", err
+	}
+
+	dateCompleted := time.Now().UTC()
+	var task *Task
+	undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+		if !force {
+			err := service.checkUnblocked(taskId, ownerId)
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		task, err = service.Repository.CompleteTask(taskId, ownerId, &dateCompleted)
+		if errors.Is(err, ErrTaskNotFound) {
+			task, err = service.Repository.GetTask(taskId, ownerId)
+		} else if err == nil {
+			err = service.continueSeries(task, false, ownerId)
+		}
+		if err != nil || !cascade {
+			return err
+		}
+		return service.Repository.CompleteDescendants(taskId, ownerId, &dateCompleted)
+	})
+	if errors.Is(err, ErrTaskBlocked) {
+		return nil, "", err
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-CompleteTask: %v", err)
+		return nil, "", err
+	}
+	return task, undoToken, nil
+}
+
+// UncompleteTask will clear the completion date of a task and return the token that reverts it.
+func (s *ServiceImp) UncompleteTask(tokenString *string, taskId *uuid.UUID) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UncompleteTask: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UncompleteTask: %v", err)
+		return nil, "", err
+	}
+
+	var task *Task
+	undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+		var err error
+		task, err = service.Repository.UncompleteTask(taskId, ownerId)
+		return err
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UncompleteTask: %v", err)
+		return nil, "", err
+	}
+	return task, undoToken, nil
+}
+
+// RestoreTask will move a task out of the trash together with the subtasks deleted with it
+// and return the token that moves them back.
+func (s *ServiceImp) RestoreTask(tokenString *string, taskId *uuid.UUID) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-RestoreTask: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	var task *Task
+	undoToken, err := s.recordChanges(id, id, taskId, func(service *ServiceImp) error {
+		err := service.Repository.RestoreDescendants(taskId, id)
+		if err != nil {
+			return err
+		}
+
+		task, err = service.Repository.RestoreTask(taskId, id)
 		return err
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-RestoreTask: %v", err)
+		return nil, "", err
+	}
+	return task, undoToken, nil
+}
+
+// EmptyTrash will permanently delete all tasks of a user that are in the trash together with their comments,
+// shares, reminders, time entries and dependencies. It is final, no undo token is returned.
+func (s *ServiceImp) EmptyTrash(tokenString *string) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-EmptyTrash: %v", err)
+		return ErrInvalidToken
 	}
 
+	err = s.Repository.EmptyTrash(id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-EmptyTrash: %v", err)
+		return err
+	}
 	return nil
 }
 
-// NewServiceImp will create a new service with a authenticator and repository.
-func NewServiceImp(repository Repository, authenticator middleware.Authenticator) ServiceImp {
+// PatchTask will validate the changed fields and update only them.
+// If version is not nil the task is updated only if its version matches.
+// An empty patch will return the task unchanged and no token, otherwise the token reverts the patch.
+// Completing a task with open blockers is rejected with ErrTaskBlocked, only CompleteTask can force it.
+// Completing an occurrence of a recurring task will add the next occurrence of its series.
+func (s *ServiceImp) PatchTask(tokenString *string, taskId *uuid.UUID, patch *Patch, version *int64) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-PatchTask: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-PatchTask: %v", err)
+		return nil, "", err
+	}
+
+	if patch.Priority != nil {
+		ok, err := s.Repository.CheckPriority(patch.Priority)
+		if err != nil {
+			log.Printf("Error in task-ServiceImp-PatchTask: %v", err)
+			return nil, "", err
+		}
+		if !ok {
+			return nil, "", ErrInvalidPriority
+		}
+	}
+
+	if patch.Estimate != nil && !(*patch.Estimate).valid() {
+		return nil, "", ErrInvalidEstimate
+	}
+
+	if !patch.IsEmpty() {
+		var task *Task
+		undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+			current, err := service.Repository.GetTask(taskId, ownerId)
+			if err != nil {
+				return err
+			}
+
+			if patch.DateCompleted != nil && patch.DateCompleted.Valid && !current.DateCompleted.Valid {
+				err := service.checkUnblocked(taskId, ownerId)
+				if err != nil {
+					return err
+				}
+			}
+
+			task, err = service.Repository.PatchTask(taskId, ownerId, patch, version)
+			if err != nil {
+				return err
+			}
+			return service.continueSeries(task, current.DateCompleted.Valid, ownerId)
+		})
+		if errors.Is(err, ErrTaskBlocked) {
+			return nil, "", err
+		} else if err != nil {
+			log.Printf("Error in task-ServiceImp-PatchTask: %v", err)
+			return nil, "", err
+		}
+		return task, undoToken, nil
+	}
+
+	task, err := s.Repository.GetTask(taskId, ownerId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-PatchTask: %v", err)
+		return nil, "", err
+	}
+	if version != nil && task.Version != *version {
+		return nil, "", ErrVersionMismatch
+	}
+	return task, "", nil
+}
+
+// UpdateRecurrence will change the recurrence rule of a task for the occurrences in the scope and return the token that reverts it.
+// An empty rule will stop the recurrence. A task that is not recurring yet starts a new series with either scope.
+// With ScopeThis an occurrence of a series is split off into a new series of its own, or stops recurring for an empty rule,
+// and the old series continues with the occurrence that follows it.
+func (s *ServiceImp) UpdateRecurrence(tokenString *string, taskId *uuid.UUID, text string, scope RecurrenceScope) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateRecurrence: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleEditor)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateRecurrence: %v", err)
+		return nil, "", err
+	}
+
+	if scope != ScopeThis && scope != ScopeFuture {
+		return nil, "", ErrInvalidRecurrence
+	}
+
+	task, err := s.Repository.GetTask(taskId, ownerId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateRecurrence: %v", err)
+		return nil, "", err
+	}
+
+	var recurrence *Recurrence
+	if text != "" {
+		rule, err := ParseRule(text)
+		if err != nil {
+			return nil, "", err
+		}
+
+		date := task.DueDate
+		if task.Recurrence != nil {
+			date = task.Recurrence.Date
+		}
+		recurrence = newRecurrence(rule, date)
+	}
+
+	var updatedTask *Task
+	undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+		var err error
+		updatedTask, err = service.Repository.SetRecurrence(taskId, ownerId, recurrence)
+		if err != nil || task.Recurrence == nil {
+			return err
+		}
+
+		switch scope {
+		case ScopeFuture:
+			return service.Repository.UpdateFutureOccurrences(task, ownerId, recurrence)
+		case ScopeThis:
+			// The occurrence left its series for a new one or none, so the old series continues with the occurrence that follows it.
+			if !task.DateCompleted.Valid {
+				_, err = service.addNextOccurrence(task, ownerId)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateRecurrence: %v", err)
+		return nil, "", err
+	}
+
+	return updatedTask, undoToken, nil
+}
+
+// GetTaskTree will return a task with all its subtasks and their progress.
+func (s *ServiceImp) GetTaskTree(tokenString *string, taskId *uuid.UUID) (*TaskNode, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTaskTree: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleViewer)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTaskTree: %v", err)
+		return nil, err
+	}
+
+	tasks, err := s.Repository.GetSubtree(taskId, ownerId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTaskTree: %v", err)
+		return nil, err
+	}
+	return buildTree(tasks), nil
+}
+
+// MoveTask will move a task with its subtasks under a new parent and return the token that moves it back.
+// An invalid parent id will make the task a top level task.
+func (s *ServiceImp) MoveTask(tokenString *string, taskId *uuid.UUID, parentId *uuid.NullUUID) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveTask: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	ownerId, err := s.authorize(id, taskId, RoleAdmin)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveTask: %v", err)
+		return nil, "", err
+	}
+
+	var task *Task
+	undoToken, err := s.recordChanges(id, ownerId, taskId, func(service *ServiceImp) error {
+		// the owner is locked so concurrent moves can not build a cycle or nest deeper than the max depth together
+		err := service.Repository.LockUser(ownerId)
+		if err != nil {
+			return err
+		}
+
+		subtree, err := service.Repository.GetSubtree(taskId, ownerId)
+		if err != nil {
+			return err
+		}
+
+		if !parentId.Valid {
+			if height(subtree) > service.MaxDepth {
+				return ErrMaxDepthExceeded
+			}
+		} else {
+			for _, task := range subtree {
+				if task.Id == parentId.UUID {
+					return ErrInvalidParent
+				}
+			}
+
+			depth, err := service.Repository.GetDepth(&parentId.UUID, ownerId)
+			if errors.Is(err, ErrTaskNotFound) {
+				return ErrInvalidParent
+			} else if err != nil {
+				return err
+			}
+
+			if depth+height(subtree) > service.MaxDepth {
+				return ErrMaxDepthExceeded
+			}
+
+			parent, err := service.Repository.GetTask(&parentId.UUID, ownerId)
+			if err != nil {
+				return err
+			}
+
+			if parent.ListId != subtree[0].ListId {
+				err := service.Repository.MoveToList(taskId, ownerId, &parent.ListId)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		task, err = service.Repository.MoveTask(taskId, ownerId, parentId)
+		return err
+	})
+	if errors.Is(err, ErrInvalidParent) || errors.Is(err, ErrMaxDepthExceeded) {
+		return nil, "", err
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-MoveTask: %v", err)
+		return nil, "", err
+	}
+	return task, undoToken, nil
+}
+
+// NewServiceImp will create a new service with a authenticator, repository, max task depth, undo window and daily capacity.
+func NewServiceImp(repository Repository, authenticator middleware.Authenticator, maxDepth int, undoWindow time.Duration, capacity Capacity) ServiceImp {
 	return ServiceImp{
 		Repository:    repository,
 		Authenticator: authenticator,
+		MaxDepth:      maxDepth,
+		UndoWindow:    undoWindow,
+		Capacity:      capacity,
 	}
 }
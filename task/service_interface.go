@@ -1,18 +1,149 @@
 package task
 
-import "github.com/google/uuid"
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // Service defines methods for task service.
 type Service interface {
-	// GetTasks will return all tasks of a user.
-	GetTasks(*string) ([]Task, error)
+	// GetTasks will return a page of the tasks of a user matching a filter.
+	GetTasks(*string, *Filter) (*Page, error)
+
+	// SearchTasks will return the tasks of a user matching a filter and a text ordered by rank.
+	SearchTasks(*string, string, *Filter) ([]SearchResult, error)
+
+	// AddTask will add a new task to a user and return its undo token.
+	AddTask(*string, *NewTask) (*Task, string, error)
+
+	// UpdateTask will update an existing task if the version matches and return the undo token of the update.
+	UpdateTask(*string, *Task, *int64) (*Task, string, error)
+
+	// BulkTasks will run many creates, updates, completions and deletions in one transaction.
+	BulkTasks(*string, *BulkRequest) (*BulkResponse, error)
+
+	// ExportTasks will write every task of a user matching a filter as CSV.
+	ExportTasks(*string, *Filter, io.Writer) error
+
+	// ImportTasks will validate the rows of a CSV file and add them as tasks unless it is a dry run.
+	ImportTasks(*string, io.Reader, *ImportOptions) (*ImportReport, error)
+
+	// ExportTodoTxt will write every task of a user matching a filter in the todo.txt format.
+	ExportTodoTxt(*string, *Filter, io.Writer) error
+
+	// ImportTodoTxt will validate the lines of a todo.txt file and add them as tasks unless it is a dry run.
+	ImportTodoTxt(*string, io.Reader, bool) (*ImportReport, error)
+
+	// CreateFeedToken will create the secret token of the calendar feed of a user, replacing the previous one.
+	CreateFeedToken(*string) (*FeedToken, error)
+
+	// DeleteFeedToken will stop the calendar feed of a user.
+	DeleteFeedToken(*string) error
+
+	// GetCalendarFeed will write the tasks of the user of a feed token as iCalendar VTODO components.
+	GetCalendarFeed(string, *Filter, io.Writer) error
+
+	// ImportCalendar will add the VTODO and VEVENT components of an iCalendar file as tasks unless it is a dry run.
+	ImportCalendar(*string, io.Reader, bool) (*ImportReport, error)
+
+	// DeleteTask will move an existing task to the trash if the version matches, optionally with its subtasks.
+	// It returns the undo token of the deletion.
+	DeleteTask(*string, *uuid.UUID, *int64, bool) (string, error)
+
+	// CompleteTask will mark a task as completed using the server time and add the next occurrence of a recurring task.
+	// Its subtasks are optionally completed too. A task with open blockers is only completed if forced.
+	// It returns the undo token of the completion.
+	CompleteTask(*string, *uuid.UUID, bool, bool) (*Task, string, error)
+
+	// UncompleteTask will mark a task as not completed and return the undo token of the change.
+	UncompleteTask(*string, *uuid.UUID) (*Task, string, error)
+
+	// RestoreTask will move a task out of the trash with the subtasks deleted together with it and return the undo token.
+	RestoreTask(*string, *uuid.UUID) (*Task, string, error)
+
+	// EmptyTrash will permanently delete all tasks of a user in the trash, it can not be undone.
+	EmptyTrash(*string) error
+
+	// PatchTask will update only the fields changed by a patch if the version matches and return the undo token of the patch.
+	PatchTask(*string, *uuid.UUID, *Patch, *int64) (*Task, string, error)
+
+	// UpdateRecurrence will change the recurrence rule of a task for the occurrences in the scope and return its undo token.
+	UpdateRecurrence(*string, *uuid.UUID, string, RecurrenceScope) (*Task, string, error)
+
+	// GetTaskTree will return a task with all its subtasks.
+	GetTaskTree(*string, *uuid.UUID) (*TaskNode, error)
+
+	// MoveTask will move a task with its subtasks under a new parent and return its undo token.
+	MoveTask(*string, *uuid.UUID, *uuid.NullUUID) (*Task, string, error)
+
+	// AddDependency will make a task blocked by another task unless it creates a cycle.
+	AddDependency(*string, *uuid.UUID, *uuid.UUID) (*Task, error)
+
+	// RemoveDependency will stop a task from being blocked by another task.
+	RemoveDependency(*string, *uuid.UUID, *uuid.UUID) (*Task, error)
+
+	// GetBlockers will return the tasks blocking a task.
+	GetBlockers(*string, *uuid.UUID) ([]Task, error)
+
+	// GetWorkload will return the estimates of the open tasks of a user by due date in a time zone.
+	GetWorkload(*string, *time.Location) (*Workload, error)
+
+	// RankTask will move a task right before or after another task in the manual order.
+	RankTask(*string, *uuid.UUID, *uuid.UUID, Placement) (*Task, string, error)
+
+	// GetTaskHistory will return a page of the history of a task.
+	GetTaskHistory(*string, *uuid.UUID, *HistoryCursor, int) (*HistoryPage, error)
+
+	// GetActivity will return a page of the history of the tasks of a user.
+	GetActivity(*string, *HistoryCursor, int) (*HistoryPage, error)
+
+	// Undo will revert the change of an undo token and return the task and the token that reverts the undo.
+	Undo(*string, string) (*Task, string, error)
+
+	// GetTags will return all tags of a user.
+	GetTags(*string) ([]Tag, error)
+
+	// AddTag will add a new tag to a user.
+	AddTag(*string, *NewTag) (*Tag, error)
+
+	// UpdateTag will rename and recolor a tag.
+	UpdateTag(*string, *Tag) (*Tag, error)
+
+	// DeleteTag will delete a tag and detach it from all tasks.
+	DeleteTag(*string, *uuid.UUID) error
+
+	// AttachTags will attach tags to a task.
+	AttachTags(*string, *uuid.UUID, []uuid.UUID) (*Task, error)
+
+	// DetachTags will detach tags from a task.
+	DetachTags(*string, *uuid.UUID, []uuid.UUID) (*Task, error)
+
+	// GetLists will return the lists of a user, archived lists are included only if asked for.
+	GetLists(*string, bool) ([]List, error)
+
+	// AddList will add a new list to a user.
+	AddList(*string, *NewList) (*List, error)
+
+	// UpdateList will rename, recolor, reorder and archive a list.
+	UpdateList(*string, *List) (*List, error)
+
+	// DeleteList will delete a list and move its tasks to the inbox.
+	DeleteList(*string, *uuid.UUID) error
+
+	// MoveToList will move a task with its subtasks to another list and return its undo token.
+	MoveToList(*string, *uuid.UUID, *uuid.UUID) (*Task, string, error)
+
+	// GetShares will return the shares of a task or a list.
+	GetShares(*string, *ShareTarget) ([]Share, error)
 
-	// AddTask will add a new task to a user.
-	AddTask(*string, *NewTask) (*Task, error)
+	// AddShare will share a task or a list with the user registered with an email.
+	AddShare(*string, *NewShare) (*Share, error)
 
-	// UpdateTask will update an existing task.
-	UpdateTask(*string, *Task) (*Task, error)
+	// UpdateShare will change the role of a share.
+	UpdateShare(*string, *ShareUpdate) (*Share, error)
 
-	// DeleteTask will delete an existing task.
-	DeleteTask(*string, *uuid.UUID) error
+	// DeleteShare will revoke a share.
+	DeleteShare(*string, *uuid.UUID) error
 }
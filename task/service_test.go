@@ -0,0 +1,194 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCompleteTask(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+	task := repository.addTask(userId, "Report", nil)
+
+	start := time.Now().UTC()
+	completed, undoToken, err := service.CompleteTask(&token, &task.Id, false, false)
+	if err != nil {
+		t.Fatalf("CompleteTask returned %v", err)
+	}
+	dateCompleted := completed.DateCompleted.Time
+	if !completed.DateCompleted.Valid || dateCompleted.Before(start) || dateCompleted.After(time.Now().UTC()) {
+		t.Errorf("completion date = %v, want the server time of the request", completed.DateCompleted)
+	}
+	if completed.Version != task.Version+1 || undoToken == "" {
+		t.Errorf("completed task has version %d and undo token %q", completed.Version, undoToken)
+	}
+	if len(repository.history) != 1 || repository.history[0].Action != ActionComplete {
+		t.Errorf("history = %+v, want a single complete entry", repository.history)
+	}
+
+	again, undoToken, err := service.CompleteTask(&token, &task.Id, false, false)
+	if err != nil {
+		t.Fatalf("CompleteTask of a completed task returned %v", err)
+	}
+	if !again.DateCompleted.Time.Equal(dateCompleted) || again.Version != completed.Version || undoToken != "" {
+		t.Errorf("completing again = %v, version %d, undo token %q, want the task unchanged", again.DateCompleted, again.Version, undoToken)
+	}
+	if len(repository.history) != 1 {
+		t.Errorf("completing again added history %+v", repository.history[1:])
+	}
+}
+
+func TestCompleteTaskCascade(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+	root := repository.addTask(userId, "Trip", nil)
+	child := repository.addTask(userId, "Book hotel", &root)
+	grandchild := repository.addTask(userId, "Compare prices", &child)
+
+	_, _, err := service.CompleteTask(&token, &child.Id, false, false)
+	if err != nil {
+		t.Fatalf("CompleteTask returned %v", err)
+	}
+	if repository.tasks[grandchild.Id].DateCompleted.Valid {
+		t.Error("completing without cascade completed the subtask")
+	}
+
+	_, _, err = service.CompleteTask(&token, &root.Id, true, false)
+	if err != nil {
+		t.Fatalf("CompleteTask with cascade returned %v", err)
+	}
+	for _, task := range []Task{root, child, grandchild} {
+		if !repository.tasks[task.Id].DateCompleted.Valid {
+			t.Errorf("%s is not completed", task.Name)
+		}
+	}
+	if !repository.tasks[child.Id].DateCompleted.Time.Before(repository.tasks[root.Id].DateCompleted.Time) {
+		t.Error("cascading overwrote the completion date of an already completed subtask")
+	}
+}
+
+func TestUncompleteTask(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+	task := repository.addTask(userId, "Report", nil)
+
+	_, _, err := service.CompleteTask(&token, &task.Id, false, false)
+	if err != nil {
+		t.Fatalf("CompleteTask returned %v", err)
+	}
+
+	uncompleted, undoToken, err := service.UncompleteTask(&token, &task.Id)
+	if err != nil {
+		t.Fatalf("UncompleteTask returned %v", err)
+	}
+	if uncompleted.DateCompleted.Valid || undoToken == "" {
+		t.Errorf("uncompleted task = %v with undo token %q", uncompleted.DateCompleted, undoToken)
+	}
+	if last := repository.history[len(repository.history)-1]; last.Action != ActionUncomplete {
+		t.Errorf("last history entry = %s, want %s", last.Action, ActionUncomplete)
+	}
+}
+
+func TestCompleteTaskOfAnotherUser(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	task := repository.addTask(uuid.New(), "Report", nil)
+	token := uuid.New().String()
+
+	_, _, err := service.CompleteTask(&token, &task.Id, false, false)
+	if !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("CompleteTask returned %v, want %v", err, ErrTaskNotFound)
+	}
+	_, _, err = service.UncompleteTask(&token, &task.Id)
+	if !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("UncompleteTask returned %v, want %v", err, ErrTaskNotFound)
+	}
+	if repository.tasks[task.Id].Version != task.Version {
+		t.Error("the task of another user was changed")
+	}
+}
+
+func TestUpdateBlockedTask(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+	blocker := repository.addTask(userId, "Approval", nil)
+	open := repository.addTask(userId, "Release", nil)
+	completed := repository.addTask(userId, "Announcement", nil)
+	stored := repository.tasks[completed.Id]
+	stored.DateCompleted = NullTime{sqlTime(time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC))}
+	repository.tasks[completed.Id] = stored
+	repository.dependencies = []Dependency{{TaskId: open.Id, BlockerId: blocker.Id}, {TaskId: completed.Id, BlockerId: blocker.Id}}
+
+	edited := stored.Task
+	edited.Name = "Announcement draft"
+	_, _, err := service.UpdateTask(&token, &edited, nil)
+	if err != nil {
+		t.Errorf("editing a completed task with an open blocker returned %v", err)
+	}
+
+	completing := open
+	completing.DateCompleted = NullTime{sqlTime(time.Now().UTC())}
+	_, _, err = service.UpdateTask(&token, &completing, nil)
+	if !errors.Is(err, ErrTaskBlocked) {
+		t.Errorf("completing a task with an open blocker returned %v, want %v", err, ErrTaskBlocked)
+	}
+	if repository.tasks[open.Id].DateCompleted.Valid {
+		t.Error("the blocked task was completed")
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	repository := newMemoryRepository()
+	service := newFakeService(repository)
+	userId := uuid.New()
+	token := userId.String()
+	root := repository.addTask(userId, "Trip", nil)
+	child := repository.addTask(userId, "Book hotel", &root)
+	grandchild := repository.addTask(userId, "Compare prices", &child)
+	other := repository.addTask(userId, "Report", nil)
+	otherChild := repository.addTask(userId, "Draft", &other)
+
+	tests := []struct {
+		name     string
+		task     Task
+		parentId uuid.NullUUID
+		want     error
+	}{
+		{"onto itself", root, uuid.NullUUID{UUID: root.Id, Valid: true}, ErrInvalidParent},
+		{"under its subtask", root, uuid.NullUUID{UUID: child.Id, Valid: true}, ErrInvalidParent},
+		{"under a deeper subtask", root, uuid.NullUUID{UUID: grandchild.Id, Valid: true}, ErrInvalidParent},
+		{"under a missing task", root, uuid.NullUUID{UUID: uuid.New(), Valid: true}, ErrInvalidParent},
+		{"too deep", child, uuid.NullUUID{UUID: otherChild.Id, Valid: true}, ErrMaxDepthExceeded},
+		{"under another task", child, uuid.NullUUID{UUID: other.Id, Valid: true}, nil},
+		{"to the top", grandchild, uuid.NullUUID{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := repository.tasks[tt.task.Id].ParentId
+			moved, _, err := service.MoveTask(&token, &tt.task.Id, &tt.parentId)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("MoveTask returned %v, want %v", err, tt.want)
+			}
+
+			parentId := repository.tasks[tt.task.Id].ParentId
+			if tt.want != nil && parentId != before {
+				t.Errorf("a rejected move changed the parent to %v", parentId)
+			}
+			if tt.want == nil && (parentId != tt.parentId || moved.ParentId != tt.parentId) {
+				t.Errorf("parent = %v, want %v", parentId, tt.parentId)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package task
+
+import "github.com/google/uuid"
+
+// Role is the access a user has to a task or a list.
+type Role string
+
+const (
+	// RoleViewer can read the shared tasks.
+	RoleViewer Role = "viewer"
+	// RoleEditor can also update and complete the shared tasks.
+	RoleEditor Role = "editor"
+	// RoleAdmin can also delete and move the shared tasks and manage their shares.
+	RoleAdmin Role = "admin"
+	// RoleOwner is the role of the user owning a task or a list, it cannot be granted.
+	RoleOwner Role = "owner"
+)
+
+// roleRanks orders the roles, a role allows everything allowed by the roles ranked below it.
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// allows will check if the role grants at least the required role.
+func (r Role) allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+// grantable will check if the role can be granted to another user.
+func (r Role) grantable() bool {
+	return r == RoleViewer || r == RoleEditor || r == RoleAdmin
+}
+
+// ShareTarget is the task or the list that is shared, exactly one of them is set.
+type ShareTarget struct {
+	TaskId uuid.NullUUID `json:"taskId"`
+	ListId uuid.NullUUID `json:"listId"`
+}
+
+// valid will check that exactly one of the task and the list is set.
+func (t *ShareTarget) valid() bool {
+	return t.TaskId.Valid != t.ListId.Valid
+}
+
+// Share grants a user a role on a task or on all tasks of a list.
+type Share struct {
+	Id uuid.UUID `json:"id"`
+	ShareTarget
+	UserId uuid.UUID `json:"userId"`
+	Email  string    `json:"email"`
+	Role   Role      `json:"role"`
+}
+
+// NewShare is a share that will be added, the recipient is found by email.
+type NewShare struct {
+	ShareTarget
+	Email string `json:"email"`
+	Role  Role   `json:"role"`
+}
+
+// ShareUpdate is the body of a request changing the role of a share.
+type ShareUpdate struct {
+	Id   uuid.UUID `json:"id"`
+	Role Role      `json:"role"`
+}
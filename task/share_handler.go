@@ -0,0 +1,198 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// handleInvalidShare will respond each time a share has no single target, an invalid role or is granted to the owner.
+func (h *HandlerImp) handleInvalidShare(w http.ResponseWriter) {
+	http.Error(w, "Invalid share", http.StatusBadRequest)
+}
+
+// handleShareError will respond to the errors shared by the share handlers.
+// It returns false if the error is not one of them.
+func (h *HandlerImp) handleShareError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, ErrInvalidShare):
+		h.handleInvalidShare(w)
+	case errors.Is(err, ErrShareNotFound):
+		http.Error(w, "Share not found", http.StatusNotFound)
+	case errors.Is(err, ErrTaskNotFound):
+		h.handleTaskNotFound(w)
+	case errors.Is(err, ErrListNotFound):
+		h.handleListNotFound(w)
+	case errors.Is(err, ErrForbidden):
+		h.handleForbidden(w)
+	case errors.Is(err, ErrInvalidToken):
+		h.handleInvalidToken(w)
+	default:
+		return false
+	}
+	return true
+}
+
+// writeShare will respond with a share.
+func (h *HandlerImp) writeShare(w http.ResponseWriter, share *Share) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(share)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-writeShare: %v", err)
+	}
+}
+
+// HandleGetShares will handle get requests and send the shares of the task or the list in the task or list query parameter.
+func (h *HandlerImp) HandleGetShares(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var target ShareTarget
+	if value := r.URL.Query().Get("task"); value != "" {
+		target.TaskId.UUID, err = uuid.Parse(value)
+		if err != nil {
+			h.handleInvalidId(w)
+			return
+		}
+		target.TaskId.Valid = true
+	}
+	if value := r.URL.Query().Get("list"); value != "" {
+		target.ListId.UUID, err = uuid.Parse(value)
+		if err != nil {
+			h.handleInvalidId(w)
+			return
+		}
+		target.ListId.Valid = true
+	}
+
+	shares, err := h.Service.GetShares(&token, &target)
+	if h.handleShareError(w, err) {
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetShares: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(shares)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetShares: %v", err)
+	}
+}
+
+// HandlePostShare will handle post requests for sharing a task or a list.
+func (h *HandlerImp) HandlePostShare(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedShare NewShare
+	err = json.NewDecoder(r.Body).Decode(&receivedShare)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	share, err := h.Service.AddShare(&token, &receivedShare)
+	if errors.Is(err, ErrUserNotFound) {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	} else if errors.Is(err, ErrShareExists) {
+		http.Error(w, "User already has a share", http.StatusConflict)
+		return
+	} else if h.handleShareError(w, err) {
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePostShare: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeShare(w, share)
+}
+
+// HandlePutShare will handle put requests for changing the role of a share.
+func (h *HandlerImp) HandlePutShare(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var update ShareUpdate
+	err = json.NewDecoder(r.Body).Decode(&update)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	share, err := h.Service.UpdateShare(&token, &update)
+	if h.handleShareError(w, err) {
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePutShare: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeShare(w, share)
+}
+
+// HandleDeleteShare will handle delete requests for revoking a share.
+func (h *HandlerImp) HandleDeleteShare(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	err = h.Service.DeleteShare(&token, &id)
+	if h.handleShareError(w, err) {
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleDeleteShare: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,215 @@
+package task
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// shareColumns are the columns selected for every share, s is the shares table and u the recipient.
+const shareColumns = "s.id, s.task_id, s.list_id, s.user_id, u.email, s.role"
+
+// shareRank orders the roles of the shares table so the highest role can be selected.
+const shareRank = "CASE s.role WHEN 'admin' THEN 3 WHEN 'editor' THEN 2 ELSE 1 END"
+
+// sharedTasksCondition matches the tasks shared with the user $1 directly or through their list.
+const sharedTasksCondition = "(id IN (SELECT task_id FROM shares WHERE user_id = $1) OR list_id IN (SELECT list_id FROM shares WHERE user_id = $1))"
+
+// scanShare will scan a row selected with shareColumns.
+func scanShare(row rowScanner) (*Share, error) {
+	var share Share
+	err := row.Scan(&share.Id, &share.TaskId, &share.ListId, &share.UserId, &share.Email, &share.Role)
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// scanAccess will scan the owner and the role of a user selected by an access query.
+// A missing row or role means the user has no access.
+func scanAccess(row *sql.Row, notFound error) (*uuid.UUID, Role, error) {
+	var ownerId uuid.UUID
+	var role sql.NullString
+	err := row.Scan(&ownerId, &role)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && !role.Valid {
+		return nil, "", notFound
+	} else if err != nil {
+		return nil, "", err
+	}
+	return &ownerId, Role(role.String), nil
+}
+
+// GetTaskAccess will get the owner of a task and the highest role a user has on it.
+// A task in the trash is not found, so nothing can be read or written through it until it is restored.
+func (r *PostgresRepository) GetTaskAccess(taskId *uuid.UUID, userId *uuid.UUID) (*uuid.UUID, Role, error) {
+	query := "SELECT t.user_id, CASE WHEN t.user_id = $2 THEN 'owner' ELSE " +
+		"(SELECT s.role FROM shares s WHERE s.user_id = $2 AND (s.task_id = t.id OR s.list_id = t.list_id) ORDER BY " + shareRank + " DESC LIMIT 1) END " +
+		"FROM tasks t WHERE t.id = $1 AND t.date_deleted IS NULL"
+	log.Printf("Executing query in task-PostgresRepository-GetTaskAccess: %s | Parameters %s, %s", query, taskId, userId)
+
+	ownerId, role, err := scanAccess(r.database.QueryRow(query, *taskId, *userId), ErrTaskNotFound)
+	if err != nil && !errors.Is(err, ErrTaskNotFound) {
+		log.Printf("Error in task-PostgresRepository-GetTaskAccess: %v", err)
+	}
+	return ownerId, role, err
+}
+
+// GetListAccess will get the owner of a list and the highest role a user has on it.
+func (r *PostgresRepository) GetListAccess(listId *uuid.UUID, userId *uuid.UUID) (*uuid.UUID, Role, error) {
+	query := "SELECT l.user_id, CASE WHEN l.user_id = $2 THEN 'owner' ELSE " +
+		"(SELECT s.role FROM shares s WHERE s.user_id = $2 AND s.list_id = l.id ORDER BY " + shareRank + " DESC LIMIT 1) END " +
+		"FROM lists l WHERE l.id = $1"
+	log.Printf("Executing query in task-PostgresRepository-GetListAccess: %s | Parameters %s, %s", query, listId, userId)
+
+	ownerId, role, err := scanAccess(r.database.QueryRow(query, *listId, *userId), ErrListNotFound)
+	if err != nil && !errors.Is(err, ErrListNotFound) {
+		log.Printf("Error in task-PostgresRepository-GetListAccess: %v", err)
+	}
+	return ownerId, role, err
+}
+
+// GetUserIdByEmail will get the id of the registered user with an email.
+func (r *PostgresRepository) GetUserIdByEmail(email string) (*uuid.UUID, error) {
+	query := "SELECT id FROM users WHERE email = $1"
+	log.Printf("Executing query in task-PostgresRepository-GetUserIdByEmail: %s | Parameters %s", query, email)
+
+	var userId uuid.UUID
+	err := r.database.QueryRow(query, email).Scan(&userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetUserIdByEmail: %v", err)
+		return nil, err
+	}
+	return &userId, nil
+}
+
+// GetShares will get the shares of a task or a list.
+func (r *PostgresRepository) GetShares(target *ShareTarget) ([]Share, error) {
+	query := "SELECT " + shareColumns + " FROM shares s JOIN users u ON u.id = s.user_id " +
+		"WHERE s.task_id IS NOT DISTINCT FROM $1 AND s.list_id IS NOT DISTINCT FROM $2 ORDER BY u.email"
+	log.Printf("Executing query in task-PostgresRepository-GetShares: %s | Parameters %v, %v", query, target.TaskId, target.ListId)
+
+	rows, err := r.database.Query(query, target.TaskId, target.ListId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetShares: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	shares := make([]Share, 0)
+	for rows.Next() {
+		share, err := scanShare(rows)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetShares: %v", err)
+			return nil, err
+		}
+		shares = append(shares, *share)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetShares: %v", err)
+		return nil, err
+	}
+	return shares, nil
+}
+
+// GetShare will get a share by its id.
+func (r *PostgresRepository) GetShare(shareId *uuid.UUID) (*Share, error) {
+	query := "SELECT " + shareColumns + " FROM shares s JOIN users u ON u.id = s.user_id WHERE s.id = $1"
+	log.Printf("Executing query in task-PostgresRepository-GetShare: %s | Parameters %s", query, shareId)
+
+	share, err := scanShare(r.database.QueryRow(query, *shareId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrShareNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetShare: %v", err)
+		return nil, err
+	}
+	return share, nil
+}
+
+// CheckShare will check that a user has no share on a task or a list yet.
+func (r *PostgresRepository) CheckShare(target *ShareTarget, userId *uuid.UUID) (bool, error) {
+	query := "SELECT COUNT(id) FROM shares WHERE task_id IS NOT DISTINCT FROM $1 AND list_id IS NOT DISTINCT FROM $2 AND user_id = $3"
+	log.Printf("Executing query in task-PostgresRepository-CheckShare: %s | Parameters %v, %v, %s", query, target.TaskId, target.ListId, userId)
+
+	var count int64
+	err := r.database.QueryRow(query, target.TaskId, target.ListId, *userId).Scan(&count)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-CheckShare: %v", err)
+		return false, err
+	}
+	return count == 0, nil
+}
+
+// AddShare will add a new share.
+func (r *PostgresRepository) AddShare(share *Share) error {
+	query := "INSERT INTO shares(id, task_id, list_id, user_id, role) VALUES ($1, $2, $3, $4, $5)"
+	log.Printf("Executing query in task-PostgresRepository-AddShare: %s | Parameters %s, %v, %v, %s, %s", query, share.Id, share.TaskId, share.ListId, share.UserId, share.Role)
+
+	_, err := r.database.Exec(query, share.Id, share.TaskId, share.ListId, share.UserId, share.Role)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddShare: %v", err)
+	}
+	return err
+}
+
+// UpdateShare will change the role of a share.
+func (r *PostgresRepository) UpdateShare(shareId *uuid.UUID, role Role) error {
+	query := "UPDATE shares SET role = $1 WHERE id = $2"
+	log.Printf("Executing query in task-PostgresRepository-UpdateShare: %s | Parameters %s, %s", query, role, shareId)
+
+	result, err := r.database.Exec(query, role, *shareId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-UpdateShare: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-UpdateShare: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// DeleteShare will revoke a share.
+func (r *PostgresRepository) DeleteShare(shareId *uuid.UUID) error {
+	query := "DELETE FROM shares WHERE id = $1"
+	log.Printf("Executing query in task-PostgresRepository-DeleteShare: %s | Parameters %s", query, shareId)
+
+	result, err := r.database.Exec(query, *shareId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteShare: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteShare: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// CopyShares will grant the shares of a task on another task.
+func (r *PostgresRepository) CopyShares(fromTaskId *uuid.UUID, toTaskId *uuid.UUID) error {
+	query := "INSERT INTO shares(id, task_id, user_id, role) SELECT gen_random_uuid(), $2, user_id, role FROM shares WHERE task_id = $1"
+	log.Printf("Executing query in task-PostgresRepository-CopyShares: %s | Parameters %s, %s", query, fromTaskId, toTaskId)
+
+	_, err := r.database.Exec(query, *fromTaskId, *toTaskId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-CopyShares: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+package task
+
+import (
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// authorize will check that a user has at least the required role on a task and return the id of its owner.
+// A user without any role gets ErrTaskNotFound so the task stays hidden from them.
+func (s *ServiceImp) authorize(userId *uuid.UUID, taskId *uuid.UUID, required Role) (*uuid.UUID, error) {
+	ownerId, role, err := s.Repository.GetTaskAccess(taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	if !role.allows(required) {
+		return nil, ErrForbidden
+	}
+	return ownerId, nil
+}
+
+// authorizeTarget will check that a user has at least the required role on the task or the list of a share.
+// It returns the id of the owner and the role of the user.
+func (s *ServiceImp) authorizeTarget(userId *uuid.UUID, target *ShareTarget, required Role) (*uuid.UUID, Role, error) {
+	var ownerId *uuid.UUID
+	var role Role
+	var err error
+	if target.TaskId.Valid {
+		ownerId, role, err = s.Repository.GetTaskAccess(&target.TaskId.UUID, userId)
+	} else {
+		ownerId, role, err = s.Repository.GetListAccess(&target.ListId.UUID, userId)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if !role.allows(required) {
+		return nil, "", ErrForbidden
+	}
+	return ownerId, role, nil
+}
+
+// GetShares will return the shares of a task or a list, only its owner and admins can see them.
+func (s *ServiceImp) GetShares(tokenString *string, target *ShareTarget) ([]Share, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetShares: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !target.valid() {
+		return nil, ErrInvalidShare
+	}
+
+	_, _, err = s.authorizeTarget(id, target, RoleAdmin)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetShares: %v", err)
+		return nil, err
+	}
+
+	shares, err := s.Repository.GetShares(target)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetShares: %v", err)
+		return nil, err
+	}
+	return shares, nil
+}
+
+// AddShare will share a task or a list with the user registered with an email.
+// Admins cannot grant a role higher than their own and the owner cannot share with themselves.
+func (s *ServiceImp) AddShare(tokenString *string, newShare *NewShare) (*Share, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddShare: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !newShare.valid() || !newShare.Role.grantable() {
+		return nil, ErrInvalidShare
+	}
+
+	ownerId, role, err := s.authorizeTarget(id, &newShare.ShareTarget, RoleAdmin)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddShare: %v", err)
+		return nil, err
+	}
+	if !role.allows(newShare.Role) {
+		return nil, ErrForbidden
+	}
+
+	userId, err := s.Repository.GetUserIdByEmail(newShare.Email)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddShare: %v", err)
+		return nil, err
+	}
+	if *userId == *ownerId {
+		return nil, ErrInvalidShare
+	}
+
+	ok, err := s.Repository.CheckShare(&newShare.ShareTarget, userId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddShare: %v", err)
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrShareExists
+	}
+
+	share := &Share{
+		Id:          uuid.New(),
+		ShareTarget: newShare.ShareTarget,
+		UserId:      *userId,
+		Email:       newShare.Email,
+		Role:        newShare.Role,
+	}
+	err = s.Repository.AddShare(share)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddShare: %v", err)
+		return nil, err
+	}
+	return share, nil
+}
+
+// UpdateShare will change the role of a share.
+// Admins cannot change the role of a share to or from a role higher than their own.
+func (s *ServiceImp) UpdateShare(tokenString *string, update *ShareUpdate) (*Share, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateShare: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !update.Role.grantable() {
+		return nil, ErrInvalidShare
+	}
+
+	share, err := s.Repository.GetShare(&update.Id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateShare: %v", err)
+		return nil, err
+	}
+
+	_, role, err := s.authorizeTarget(id, &share.ShareTarget, RoleAdmin)
+	if errors.Is(err, ErrTaskNotFound) || errors.Is(err, ErrListNotFound) {
+		return nil, ErrShareNotFound
+	} else if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateShare: %v", err)
+		return nil, err
+	}
+	if !role.allows(update.Role) || !role.allows(share.Role) {
+		return nil, ErrForbidden
+	}
+
+	err = s.Repository.UpdateShare(&share.Id, update.Role)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateShare: %v", err)
+		return nil, err
+	}
+
+	share.Role = update.Role
+	return share, nil
+}
+
+// DeleteShare will revoke a share.
+// The owner and admins can revoke the shares of others, every user can give up their own share.
+func (s *ServiceImp) DeleteShare(tokenString *string, shareId *uuid.UUID) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteShare: %v", err)
+		return ErrInvalidToken
+	}
+
+	share, err := s.Repository.GetShare(shareId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteShare: %v", err)
+		return err
+	}
+
+	if share.UserId != *id {
+		_, role, err := s.authorizeTarget(id, &share.ShareTarget, RoleAdmin)
+		if errors.Is(err, ErrTaskNotFound) || errors.Is(err, ErrListNotFound) {
+			return ErrShareNotFound
+		} else if err != nil {
+			log.Printf("Error in task-ServiceImp-DeleteShare: %v", err)
+			return err
+		}
+		if !role.allows(share.Role) {
+			return ErrForbidden
+		}
+	}
+
+	err = s.Repository.DeleteShare(shareId)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteShare: %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		want     bool
+	}{
+		{RoleViewer, RoleViewer, true},
+		{RoleViewer, RoleEditor, false},
+		{RoleEditor, RoleViewer, true},
+		{RoleEditor, RoleAdmin, false},
+		{RoleAdmin, RoleEditor, true},
+		{RoleAdmin, RoleOwner, false},
+		{RoleOwner, RoleAdmin, true},
+		{Role(""), RoleViewer, false},
+		{Role("superuser"), RoleViewer, false},
+	}
+
+	for _, test := range tests {
+		got := test.role.allows(test.required)
+		if got != test.want {
+			t.Errorf("%q.allows(%q) = %t, want %t", test.role, test.required, got, test.want)
+		}
+	}
+}
+
+func TestRoleGrantable(t *testing.T) {
+	tests := map[Role]bool{
+		RoleViewer:  true,
+		RoleEditor:  true,
+		RoleAdmin:   true,
+		RoleOwner:   false,
+		Role(""):    false,
+		Role("ADM"): false,
+	}
+
+	for role, want := range tests {
+		if got := role.grantable(); got != want {
+			t.Errorf("%q.grantable() = %t, want %t", role, got, want)
+		}
+	}
+}
+
+func TestShareTargetValid(t *testing.T) {
+	id := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+
+	tests := []struct {
+		name   string
+		target ShareTarget
+		want   bool
+	}{
+		{"task", ShareTarget{TaskId: id}, true},
+		{"list", ShareTarget{ListId: id}, true},
+		{"neither", ShareTarget{}, false},
+		{"both", ShareTarget{TaskId: id, ListId: id}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.target.valid(); got != test.want {
+			t.Errorf("valid(%s) = %t, want %t", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package task
+
+import "github.com/google/uuid"
+
+// Progress is the number of completed children of a task out of all its children.
+type Progress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// TaskNode is a task together with its subtasks.
+type TaskNode struct {
+	Task
+	Progress Progress   `json:"progress"`
+	Children []TaskNode `json:"children"`
+}
+
+// buildTree will build the tree of a subtree returned by Repository.GetSubtree.
+func buildTree(tasks []Task) *TaskNode {
+	children := make(map[uuid.UUID][]Task)
+	for _, task := range tasks[1:] {
+		children[task.ParentId.UUID] = append(children[task.ParentId.UUID], task)
+	}
+
+	var build func(task Task) TaskNode
+	build = func(task Task) TaskNode {
+		node := TaskNode{Task: task, Children: make([]TaskNode, 0, len(children[task.Id]))}
+		for _, child := range children[task.Id] {
+			node.Children = append(node.Children, build(child))
+			if child.DateCompleted.Valid {
+				node.Progress.Completed++
+			}
+		}
+		node.Progress.Total = len(node.Children)
+		return node
+	}
+
+	root := build(tasks[0])
+	return &root
+}
+
+// height will return the number of levels of a subtree returned by Repository.GetSubtree.
+func height(tasks []Task) int {
+	levels := map[uuid.UUID]int{tasks[0].Id: 1}
+	result := 1
+	for _, task := range tasks[1:] {
+		level := levels[task.ParentId.UUID] + 1
+		levels[task.Id] = level
+		result = max(result, level)
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package task
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// subtask will return a task with a parent, completed if asked for.
+func subtask(name string, parent *Task, completed bool) Task {
+	task := Task{Id: uuid.New(), Name: name}
+	if parent != nil {
+		task.ParentId = uuid.NullUUID{UUID: parent.Id, Valid: true}
+	}
+	if completed {
+		task.DateCompleted = NullTime{sql.NullTime{Time: time.Now(), Valid: true}}
+	}
+	return task
+}
+
+func TestBuildTree(t *testing.T) {
+	root := subtask("root", nil, false)
+	a := subtask("a", &root, true)
+	b := subtask("b", &root, false)
+	a1 := subtask("a1", &a, true)
+	a2 := subtask("a2", &a, true)
+	b1 := subtask("b1", &b, false)
+
+	tree := buildTree([]Task{root, a, b, a1, a2, b1})
+
+	if tree.Id != root.Id || len(tree.Children) != 2 {
+		t.Fatalf("buildTree root = %s with %d children, want %s with 2", tree.Name, len(tree.Children), root.Name)
+	}
+	if tree.Progress != (Progress{Completed: 1, Total: 2}) {
+		t.Errorf("root progress = %+v, want 1 of 2", tree.Progress)
+	}
+
+	tests := []struct {
+		node     TaskNode
+		name     string
+		children []string
+		progress Progress
+	}{
+		{tree.Children[0], "a", []string{"a1", "a2"}, Progress{Completed: 2, Total: 2}},
+		{tree.Children[1], "b", []string{"b1"}, Progress{Completed: 0, Total: 1}},
+		{tree.Children[0].Children[0], "a1", []string{}, Progress{}},
+	}
+
+	for _, test := range tests {
+		if test.node.Name != test.name {
+			t.Errorf("node = %s, want %s", test.node.Name, test.name)
+			continue
+		}
+		if len(test.node.Children) != len(test.children) {
+			t.Errorf("%s has %d children, want %d", test.name, len(test.node.Children), len(test.children))
+			continue
+		}
+		for i, child := range test.node.Children {
+			if child.Name != test.children[i] {
+				t.Errorf("child %d of %s = %s, want %s", i, test.name, child.Name, test.children[i])
+			}
+		}
+		if test.node.Progress != test.progress {
+			t.Errorf("progress of %s = %+v, want %+v", test.name, test.node.Progress, test.progress)
+		}
+	}
+}
+
+func TestHeight(t *testing.T) {
+	root := subtask("root", nil, false)
+	a := subtask("a", &root, false)
+	b := subtask("b", &root, false)
+	a1 := subtask("a1", &a, false)
+	a11 := subtask("a11", &a1, false)
+
+	tests := []struct {
+		name  string
+		tasks []Task
+		want  int
+	}{
+		{"single task", []Task{root}, 1},
+		{"children", []Task{root, a, b}, 2},
+		{"deepest branch", []Task{root, a, b, a1, a11}, 4},
+		{"subtree of a subtask", []Task{a, a1, a11}, 3},
+	}
+
+	for _, test := range tests {
+		got := height(test.tasks)
+		if got != test.want {
+			t.Errorf("height(%s) = %d, want %d", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package task
+
+import (
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+// colorPattern matches hex colors like #1a2b3c.
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tag is a label owned by a user that can be attached to many tasks.
+type Tag struct {
+	Id    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Color string    `json:"color"`
+}
+
+// NewTag is a tag that will be added.
+type NewTag struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// TagIds is the body of a request attaching or detaching tags.
+type TagIds struct {
+	Tags []uuid.UUID `json:"tags"`
+}
+
+// checkTag will check if the name and color of a tag are valid.
+func checkTag(name string, color string) bool {
+	return name != "" && colorPattern.MatchString(color)
+}
+
+// uuidStrings will convert ids to strings so they can be sent as a postgres array.
+func uuidStrings(ids []uuid.UUID) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = id.String()
+	}
+	return result
+}
@@ -0,0 +1,234 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// handleInvalidTag will respond each time a tag has an empty name or an invalid color.
+func (h *HandlerImp) handleInvalidTag(w http.ResponseWriter) {
+	http.Error(w, "Invalid tag", http.StatusBadRequest)
+}
+
+// handleTagNotFound will respond each time a tag is not found or belongs to another user.
+func (h *HandlerImp) handleTagNotFound(w http.ResponseWriter) {
+	http.Error(w, "Tag not found", http.StatusNotFound)
+}
+
+// writeTag will respond with a tag.
+func (h *HandlerImp) writeTag(w http.ResponseWriter, tag *Tag) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(tag)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-writeTag: %v", err)
+	}
+}
+
+// HandleGetTags will handle get requests and send all tags of a user.
+func (h *HandlerImp) HandleGetTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	tags, err := h.Service.GetTags(&token)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetTags: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(tags)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetTags: %v", err)
+	}
+}
+
+// HandlePostTag will handle post requests for adding a tag.
+func (h *HandlerImp) HandlePostTag(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedTag NewTag
+	err = json.NewDecoder(r.Body).Decode(&receivedTag)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	tag, err := h.Service.AddTag(&token, &receivedTag)
+	if errors.Is(err, ErrInvalidTag) {
+		h.handleInvalidTag(w)
+		return
+	} else if errors.Is(err, ErrTagNameInUse) {
+		http.Error(w, "Tag name already in use", http.StatusConflict)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePostTag: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeTag(w, tag)
+}
+
+// HandlePutTag will handle put requests for renaming and recoloring a tag.
+func (h *HandlerImp) HandlePutTag(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedTag Tag
+	err = json.NewDecoder(r.Body).Decode(&receivedTag)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	tag, err := h.Service.UpdateTag(&token, &receivedTag)
+	if errors.Is(err, ErrInvalidTag) {
+		h.handleInvalidTag(w)
+		return
+	} else if errors.Is(err, ErrTagNameInUse) {
+		http.Error(w, "Tag name already in use", http.StatusConflict)
+		return
+	} else if errors.Is(err, ErrTagNotFound) {
+		h.handleTagNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandlePutTag: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeTag(w, tag)
+}
+
+// HandleDeleteTag will handle delete requests for deleting a tag.
+func (h *HandlerImp) HandleDeleteTag(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	err = h.Service.DeleteTag(&token, &id)
+	if errors.Is(err, ErrTagNotFound) {
+		h.handleTagNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleDeleteTag: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleTaskTags will handle the post requests that attach or detach tags of a task.
+func (h *HandlerImp) handleTaskTags(w http.ResponseWriter, r *http.Request, change func(*string, *uuid.UUID, []uuid.UUID) (*Task, error)) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	var tagIds TagIds
+	err = json.NewDecoder(r.Body).Decode(&tagIds)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	task, err := change(&token, &id, tagIds.Tags)
+	if errors.Is(err, ErrTagNotFound) {
+		h.handleTagNotFound(w)
+		return
+	} else if errors.Is(err, ErrTaskNotFound) {
+		h.handleTaskNotFound(w)
+		return
+	} else if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-handleTaskTags: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	h.writeTask(w, task)
+}
+
+// HandleAttachTags will handle post requests for attaching tags to a task.
+func (h *HandlerImp) HandleAttachTags(w http.ResponseWriter, r *http.Request) {
+	h.handleTaskTags(w, r, h.Service.AttachTags)
+}
+
+// HandleDetachTags will handle post requests for detaching tags from a task.
+func (h *HandlerImp) HandleDetachTags(w http.ResponseWriter, r *http.Request) {
+	h.handleTaskTags(w, r, h.Service.DetachTags)
+}
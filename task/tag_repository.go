@@ -0,0 +1,158 @@
+package task
+
+import (
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// GetTags will get all tags of a user ordered by name.
+func (r *PostgresRepository) GetTags(userId *uuid.UUID) ([]Tag, error) {
+	query := "SELECT id, name, color FROM tags WHERE user_id = $1 ORDER BY name"
+	log.Printf("Executing query in task-PostgresRepository-GetTags: %s | Parameters %s", query, userId)
+
+	rows, err := r.database.Query(query, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetTags: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]Tag, 0)
+	for rows.Next() {
+		var tag Tag
+		err = rows.Scan(&tag.Id, &tag.Name, &tag.Color)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetTags: %v", err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetTags: %v", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// CheckTagName will check if a user already has another tag with the name.
+func (r *PostgresRepository) CheckTagName(userId *uuid.UUID, name *string, exclude *uuid.UUID) (bool, error) {
+	query := "SELECT COUNT(id) FROM tags WHERE user_id = $1 AND name = $2 AND id <> $3"
+	log.Printf("Executing query in task-PostgresRepository-CheckTagName: %s | Parameters %s, %s, %s", query, userId, *name, exclude)
+
+	var count int64
+	err := r.database.QueryRow(query, *userId, *name, *exclude).Scan(&count)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-CheckTagName: %v", err)
+		return false, err
+	}
+	return count < 1, nil
+}
+
+// CountTags will count how many of the tags belong to a user.
+func (r *PostgresRepository) CountTags(userId *uuid.UUID, tagIds []uuid.UUID) (int, error) {
+	query := "SELECT COUNT(id) FROM tags WHERE user_id = $1 AND id = ANY($2::uuid[])"
+	log.Printf("Executing query in task-PostgresRepository-CountTags: %s | Parameters %s, %v", query, userId, tagIds)
+
+	var count int
+	err := r.database.QueryRow(query, *userId, pq.Array(uuidStrings(tagIds))).Scan(&count)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-CountTags: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// AddTag will add a new tag to a user.
+func (r *PostgresRepository) AddTag(tag *Tag, userId *uuid.UUID) error {
+	query := "INSERT INTO tags(id, name, color, user_id) VALUES ($1, $2, $3, $4)"
+	log.Printf("Executing query in task-PostgresRepository-AddTag: %s | Parameters %s, %s, %s, %s", query, tag.Id, tag.Name, tag.Color, userId)
+
+	_, err := r.database.Exec(query, tag.Id, tag.Name, tag.Color, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddTag: %v", err)
+	}
+	return err
+}
+
+// UpdateTag will rename and recolor a tag owned by a user.
+func (r *PostgresRepository) UpdateTag(tag *Tag, userId *uuid.UUID) error {
+	query := "UPDATE tags SET name = $1, color = $2 WHERE id = $3 AND user_id = $4"
+	log.Printf("Executing query in task-PostgresRepository-UpdateTag: %s | Parameters %s, %s, %s, %s", query, tag.Name, tag.Color, tag.Id, userId)
+
+	result, err := r.database.Exec(query, tag.Name, tag.Color, tag.Id, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-UpdateTag: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-UpdateTag: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// DeleteTag will delete a tag owned by a user and detach it from all tasks.
+func (r *PostgresRepository) DeleteTag(tagId *uuid.UUID, userId *uuid.UUID) error {
+	query := "DELETE FROM task_tags WHERE tag_id = (SELECT id FROM tags WHERE id = $1 AND user_id = $2)"
+	log.Printf("Executing query in task-PostgresRepository-DeleteTag: %s | Parameters %s, %s", query, tagId, userId)
+
+	_, err := r.database.Exec(query, *tagId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteTag: %v", err)
+		return err
+	}
+
+	query = "DELETE FROM tags WHERE id = $1 AND user_id = $2"
+	log.Printf("Executing query in task-PostgresRepository-DeleteTag: %s | Parameters %s, %s", query, tagId, userId)
+
+	result, err := r.database.Exec(query, *tagId, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteTag: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteTag: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// AttachTags will attach tags owned by a user to a task owned by the same user.
+// Tags that are already attached are skipped.
+func (r *PostgresRepository) AttachTags(taskId *uuid.UUID, userId *uuid.UUID, tagIds []uuid.UUID) error {
+	query := "INSERT INTO task_tags(task_id, tag_id) SELECT t.id, tg.id FROM tasks t JOIN tags tg ON tg.user_id = t.user_id " +
+		"WHERE t.id = $1 AND t.user_id = $2 AND tg.id = ANY($3::uuid[]) ON CONFLICT DO NOTHING"
+	log.Printf("Executing query in task-PostgresRepository-AttachTags: %s | Parameters %s, %s, %v", query, taskId, userId, tagIds)
+
+	_, err := r.database.Exec(query, *taskId, *userId, pq.Array(uuidStrings(tagIds)))
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AttachTags: %v", err)
+	}
+	return err
+}
+
+// DetachTags will detach tags from a task owned by a user.
+func (r *PostgresRepository) DetachTags(taskId *uuid.UUID, userId *uuid.UUID, tagIds []uuid.UUID) error {
+	query := "DELETE FROM task_tags WHERE task_id = (SELECT id FROM tasks WHERE id = $1 AND user_id = $2) AND tag_id = ANY($3::uuid[])"
+	log.Printf("Executing query in task-PostgresRepository-DetachTags: %s | Parameters %s, %s, %v", query, taskId, userId, tagIds)
+
+	_, err := r.database.Exec(query, *taskId, *userId, pq.Array(uuidStrings(tagIds)))
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DetachTags: %v", err)
+	}
+	return err
+}
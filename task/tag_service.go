@@ -0,0 +1,168 @@
+package task
+
+import (
+	"log"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// GetTags will return all tags of a user.
+func (s *ServiceImp) GetTags(tokenString *string) ([]Tag, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTags: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	tags, err := s.Repository.GetTags(id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetTags: %v", err)
+		return nil, err
+	}
+	return tags, nil
+}
+
+// checkTagName will check that the user has no other tag with the same name.
+func (s *ServiceImp) checkTagName(userId *uuid.UUID, tag *Tag) error {
+	ok, err := s.Repository.CheckTagName(userId, &tag.Name, &tag.Id)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrTagNameInUse
+	}
+	return nil
+}
+
+// AddTag will add a new tag to a user.
+func (s *ServiceImp) AddTag(tokenString *string, newTag *NewTag) (*Tag, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddTag: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkTag(newTag.Name, newTag.Color) {
+		return nil, ErrInvalidTag
+	}
+
+	tag := &Tag{
+		Id:    uuid.New(),
+		Name:  newTag.Name,
+		Color: newTag.Color,
+	}
+
+	err = s.checkTagName(id, tag)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddTag: %v", err)
+		return nil, err
+	}
+
+	err = s.Repository.AddTag(tag, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-AddTag: %v", err)
+		return nil, err
+	}
+	return tag, nil
+}
+
+// UpdateTag will rename and recolor a tag.
+func (s *ServiceImp) UpdateTag(tokenString *string, tag *Tag) (*Tag, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateTag: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkTag(tag.Name, tag.Color) {
+		return nil, ErrInvalidTag
+	}
+
+	err = s.checkTagName(id, tag)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateTag: %v", err)
+		return nil, err
+	}
+
+	err = s.Repository.UpdateTag(tag, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-UpdateTag: %v", err)
+		return nil, err
+	}
+	return tag, nil
+}
+
+// DeleteTag will delete a tag and detach it from all tasks.
+func (s *ServiceImp) DeleteTag(tokenString *string, tagId *uuid.UUID) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteTag: %v", err)
+		return ErrInvalidToken
+	}
+
+	err = s.Repository.DeleteTag(tagId, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-DeleteTag: %v", err)
+		return err
+	}
+	return nil
+}
+
+// changeTags will check the task and the tags before attaching or detaching them and return the updated task.
+func (s *ServiceImp) changeTags(tokenString *string, taskId *uuid.UUID, tagIds []uuid.UUID, attach bool) (*Task, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-changeTags: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	_, err = s.Repository.GetTask(taskId, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-changeTags: %v", err)
+		return nil, err
+	}
+
+	var unique []uuid.UUID
+	for _, tagId := range tagIds {
+		if !slices.Contains(unique, tagId) {
+			unique = append(unique, tagId)
+		}
+	}
+
+	count, err := s.Repository.CountTags(id, unique)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-changeTags: %v", err)
+		return nil, err
+	}
+	if count != len(unique) {
+		return nil, ErrTagNotFound
+	}
+
+	if attach {
+		err = s.Repository.AttachTags(taskId, id, unique)
+	} else {
+		err = s.Repository.DetachTags(taskId, id, unique)
+	}
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-changeTags: %v", err)
+		return nil, err
+	}
+
+	task, err := s.Repository.GetTask(taskId, id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-changeTags: %v", err)
+		return nil, err
+	}
+	return task, nil
+}
+
+// AttachTags will attach tags to a task.
+func (s *ServiceImp) AttachTags(tokenString *string, taskId *uuid.UUID, tagIds []uuid.UUID) (*Task, error) {
+	return s.changeTags(tokenString, taskId, tagIds, true)
+}
+
+// DetachTags will detach tags from a task.
+func (s *ServiceImp) DetachTags(tokenString *string, taskId *uuid.UUID, tagIds []uuid.UUID) (*Task, error) {
+	return s.changeTags(tokenString, taskId, tagIds, false)
+}
@@ -0,0 +1,44 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		color string
+		want  bool
+	}{
+		{"work", "#1a2b3c", true},
+		{"Work Stuff", "#ABCDEF", true},
+		{"", "#1a2b3c", false},
+		{"work", "", false},
+		{"work", "1a2b3c", false},
+		{"work", "#1a2b3", false},
+		{"work", "#1a2b3c4", false},
+		{"work", "#1a2b3g", false},
+		{"work", "red", false},
+	}
+
+	for _, test := range tests {
+		if got := checkTag(test.name, test.color); got != test.want {
+			t.Errorf("checkTag(%q, %q) = %t, want %t", test.name, test.color, got, test.want)
+		}
+	}
+}
+
+func TestUuidStrings(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+
+	got := uuidStrings([]uuid.UUID{a, b})
+	if want := []string{a.String(), b.String()}; !reflect.DeepEqual(got, want) {
+		t.Errorf("uuidStrings = %v, want %v", got, want)
+	}
+	if got := uuidStrings(nil); got == nil || len(got) != 0 {
+		t.Errorf("uuidStrings(nil) = %#v, want an empty array", got)
+	}
+}
@@ -8,13 +8,25 @@ import (
 
 // Task defines the data stored in a task.
 type Task struct {
-	Id            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	Description   string    `json:"description"`
-	Priority      int64     `json:"priority"`
-	DueDate       time.Time `json:"dueDate"`
-	DateCompleted NullTime  `json:"dateCompleted"`
-	DateDeleted   NullTime  `json:"dateDeleted"`
+	Id            uuid.UUID     `json:"id"`
+	Name          string        `json:"name"`
+	Description   string        `json:"description"`
+	Priority      int64         `json:"priority"`
+	DueDate       time.Time     `json:"dueDate"`
+	DateCompleted NullTime      `json:"dateCompleted"`
+	DateDeleted   NullTime      `json:"dateDeleted"`
+	Version       int64         `json:"version"`
+	Recurrence    *Recurrence   `json:"recurrence"`
+	ParentId      uuid.NullUUID `json:"parentId"`
+	ListId        uuid.UUID     `json:"listId"`
+	// Rank is the key of the task in the manual order of its owner, tasks are ordered by comparing the keys byte by byte.
+	Rank string `json:"rank"`
+	// Estimate is the optional effort the task is expected to take.
+	Estimate     *Estimate `json:"estimate"`
+	Tags         []Tag     `json:"tags"`
+	CommentCount int64     `json:"commentCount"`
+	// Blocked is true while a task it depends on is neither completed nor in the trash.
+	Blocked bool `json:"blocked"`
 }
 
 // NewTask is a task that will be added.
@@ -23,4 +35,13 @@ type NewTask struct {
 	Description string    `json:"description"`
 	Priority    int64     `json:"priority"`
 	DueDate     time.Time `json:"dueDate"`
+	// Recurrence is an optional RFC 5545 RRULE, the due date is the first occurrence.
+	Recurrence string `json:"recurrence"`
+	// ParentId is the optional task this task will be a subtask of.
+	ParentId uuid.NullUUID `json:"parentId"`
+	// ListId is the optional list of the task, the inbox is used when it is missing.
+	// Subtasks are always added to the list of their parent.
+	ListId uuid.NullUUID `json:"listId"`
+	// Estimate is the optional effort the task is expected to take, in minutes or points.
+	Estimate *Estimate `json:"estimate"`
 }
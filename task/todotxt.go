@@ -0,0 +1,186 @@
+package task
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// importColor is the color of the lists and tags created by a todo.txt import.
+const importColor = "#808080"
+
+// priorityLetters will assign a letter to every priority, A being the highest.
+// Priorities are ranked by name like iCalendar priorities and then by id, priorities without a known name come last.
+func priorityLetters(priorities []Priority) map[int64]byte {
+	ranked := slices.Clone(priorities)
+	rank := func(priority Priority) int {
+		level := icalPriority(priority.Name)
+		if level == 0 {
+			return 10
+		}
+		return level
+	}
+	slices.SortStableFunc(ranked, func(a Priority, b Priority) int {
+		return rank(a) - rank(b)
+	})
+
+	letters := make(map[int64]byte, len(ranked))
+	for i, priority := range ranked {
+		if i < 26 {
+			letters[priority.Id] = byte('A' + i)
+		}
+	}
+	return letters
+}
+
+// todoTxtName will write the name of a list or tag as a single word.
+func todoTxtName(name string) string {
+	return strings.Join(strings.Fields(name), "_")
+}
+
+// matchTodoTxtName will check if a name matches a word of a todo.txt line ignoring case.
+func matchTodoTxtName(name string, word string) bool {
+	return strings.EqualFold(todoTxtName(name), word)
+}
+
+// formatTodoTxtDate will write a due date as a day if it is at midnight UTC and as a time otherwise.
+func formatTodoTxtDate(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.RFC3339)
+}
+
+// isTodoTxtDate will check if a word is a todo.txt date.
+func isTodoTxtDate(word string) bool {
+	_, err := time.Parse(time.DateOnly, word)
+	return err == nil
+}
+
+// isTodoTxtPriority will check if a word is a todo.txt priority like (A).
+func isTodoTxtPriority(word string) bool {
+	return len(word) == 3 && word[0] == '(' && word[2] == ')' && word[1] >= 'A' && word[1] <= 'Z'
+}
+
+// isTodoTxtToken will check if a word would be read as a project, a context or a due: or pri: extension.
+func isTodoTxtToken(word string) bool {
+	key, value, _ := strings.Cut(word, ":")
+	return len(word) > 1 && (word[0] == '+' || word[0] == '@') ||
+		key == "due" && value != "" ||
+		key == "pri" && len(value) == 1 && value[0] >= 'A' && value[0] <= 'Z'
+}
+
+// escapeTodoTxtName will split a task name into words, escaping with a backslash the words that would not be read back as the name.
+// The first word is escaped if it would be read as the completion mark or a priority.
+func escapeTodoTxtName(name string) []string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		if word[0] == '\\' || isTodoTxtToken(word) || i == 0 && (word == "x" || isTodoTxtPriority(word)) {
+			words[i] = "\\" + word
+		}
+	}
+	return words
+}
+
+// todoTxtLine is a parsed line of a todo.txt file, it maps onto a task as
+//
+//	x 2026-01-05 Call the bank +Finance @phone due:2026-01-04 pri:A
+//	(B) Write report +Work due:2026-02-01T09:00:00Z
+//
+// The priority letter is the rank of the priority, with the highest priority being A.
+// Completed tasks start with x and the completion date and keep their priority as pri:.
+// The +project is the list of the task, tasks in the inbox have none, and the @contexts are its tags.
+// Spaces in the names of lists and tags are written as underscores.
+// The due: date is a day for tasks due at midnight UTC and an RFC 3339 time otherwise.
+// Descriptions are not part of the format, creation dates and unknown extensions stay in the name.
+// Words of the name that would be read as anything else are escaped with a backslash, which is removed when parsing.
+type todoTxtLine struct {
+	Completed bool
+	// DateCompleted is the completion date, it is empty if the line has none.
+	DateCompleted string
+	// Priority is the letter of the priority, it is 0 if the line has none.
+	Priority byte
+	Name     string
+	Projects []string
+	Contexts []string
+	Due      string
+}
+
+// parseTodoTxtLine will parse a line of a todo.txt file.
+func parseTodoTxtLine(text string) *todoTxtLine {
+	line := &todoTxtLine{}
+	words := strings.Fields(text)
+
+	if len(words) > 0 && words[0] == "x" {
+		line.Completed = true
+		words = words[1:]
+		if len(words) > 0 && isTodoTxtDate(words[0]) {
+			line.DateCompleted = words[0]
+			words = words[1:]
+		}
+	}
+
+	if len(words) > 0 && isTodoTxtPriority(words[0]) {
+		line.Priority = words[0][1]
+		words = words[1:]
+	}
+
+	var name []string
+	for _, word := range words {
+		key, value, _ := strings.Cut(word, ":")
+		switch {
+		case word[0] == '\\':
+			name = append(name, word[1:])
+		case len(word) > 1 && word[0] == '+':
+			line.Projects = append(line.Projects, word[1:])
+		case len(word) > 1 && word[0] == '@':
+			line.Contexts = append(line.Contexts, word[1:])
+		case key == "due" && value != "":
+			line.Due = value
+		case key == "pri" && len(value) == 1 && value[0] >= 'A' && value[0] <= 'Z':
+			line.Priority = value[0]
+		default:
+			name = append(name, word)
+		}
+	}
+	line.Name = strings.Join(name, " ")
+	return line
+}
+
+// writeTodoTxt will write a line for every task.
+// The list names are used for the projects, tasks of the inbox list have no project.
+func writeTodoTxt(w io.Writer, tasks []Task, listNames map[uuid.UUID]string, letters map[int64]byte) error {
+	writer := bufio.NewWriter(w)
+	for _, task := range tasks {
+		var words []string
+		letter, hasLetter := letters[task.Priority]
+		if task.DateCompleted.Valid {
+			words = append(words, "x", task.DateCompleted.Time.UTC().Format(time.DateOnly))
+		} else if hasLetter {
+			words = append(words, "("+string(letter)+")")
+		}
+
+		words = append(words, escapeTodoTxtName(task.Name)...)
+		if name, ok := listNames[task.ListId]; ok {
+			words = append(words, "+"+todoTxtName(name))
+		}
+		for _, tag := range task.Tags {
+			words = append(words, "@"+todoTxtName(tag.Name))
+		}
+		words = append(words, "due:"+formatTodoTxtDate(task.DueDate))
+		if task.DateCompleted.Valid && hasLetter {
+			words = append(words, "pri:"+string(letter))
+		}
+
+		_, err := writer.WriteString(strings.Join(words, " ") + "\n")
+		if err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
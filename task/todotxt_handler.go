@@ -0,0 +1,42 @@
+package task
+
+import (
+	"net/http"
+	"task-server/middleware"
+)
+
+// HandleExportTodoTxt will handle get requests exporting the tasks of a user in the todo.txt format.
+// It takes the same query parameters as listing tasks.
+// The format has no place for descriptions, so they are not exported and an import of the file adds tasks without them.
+func (h *HandlerImp) HandleExportTodoTxt(w http.ResponseWriter, r *http.Request) {
+	h.handleExport(w, r, h.Service.ExportTodoTxt, "text/plain; charset=utf-8", "todo.txt", "HandleExportTodoTxt")
+}
+
+// HandleImportTodoTxt will handle post requests importing tasks from a todo.txt body.
+// The dryRun query parameter only validates the lines, bodies over MaxImportSize are rejected.
+func (h *HandlerImp) HandleImportTodoTxt(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	dryRun, err := parseDryRun(r)
+	if err != nil {
+		http.Error(w, "Invalid import", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.Service.ImportTodoTxt(&token, limitImport(w, r), dryRun)
+	if err != nil {
+		h.handleImportError(w, err, "HandleImportTodoTxt")
+		return
+	}
+
+	h.writeImportReport(w, report, "HandleImportTodoTxt")
+}
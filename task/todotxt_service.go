@@ -0,0 +1,245 @@
+package task
+
+import (
+	"bufio"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportTodoTxt will write every task that belongs to or is shared with a user and matches the filter in the todo.txt format.
+func (s *ServiceImp) ExportTodoTxt(tokenString *string, filter *Filter, w io.Writer) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTodoTxt: %v", err)
+		return ErrInvalidToken
+	}
+
+	if filter.Limit < 1 || filter.Limit > MaxLimit {
+		return ErrInvalidFilter
+	}
+
+	tasks, err := s.allTasks(id, filter)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTodoTxt: %v", err)
+		return err
+	}
+
+	lists, err := s.Repository.GetLists(id, true)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTodoTxt: %v", err)
+		return err
+	}
+
+	listNames := make(map[uuid.UUID]string, len(lists))
+	for _, list := range lists {
+		if !list.Inbox {
+			listNames[list.Id] = list.Name
+		}
+	}
+
+	priorities, err := s.Repository.GetPriorities()
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTodoTxt: %v", err)
+		return err
+	}
+
+	err = writeTodoTxt(w, tasks, listNames, priorityLetters(priorities))
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ExportTodoTxt: %v", err)
+	}
+	return err
+}
+
+// todoTxtRow is a validated line of a todo.txt import with the list and tags that have to be created for it.
+type todoTxtRow struct {
+	importRow
+	newList string
+	newTags []string
+}
+
+// ImportTodoTxt will validate every line of a todo.txt file and report the errors with their line number.
+// Projects and contexts that do not match a list or tag of the user are created.
+// The tasks are added in one transaction and only if no line has an error and it is not a dry run.
+func (s *ServiceImp) ImportTodoTxt(tokenString *string, reader io.Reader, dryRun bool) (*ImportReport, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTodoTxt: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	validator, err := s.newImportValidator(id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTodoTxt: %v", err)
+		return nil, err
+	}
+
+	tags, err := s.Repository.GetTags(id)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTodoTxt: %v", err)
+		return nil, err
+	}
+
+	priorities, err := s.Repository.GetPriorities()
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTodoTxt: %v", err)
+		return nil, err
+	}
+
+	priorityIds := make(map[byte]int64, len(priorities))
+	for priorityId, letter := range priorityLetters(priorities) {
+		priorityIds[letter] = priorityId
+	}
+
+	report := &ImportReport{DryRun: dryRun, Errors: make([]ImportError, 0)}
+	rows := make([]todoTxtRow, 0)
+	scanner := bufio.NewScanner(reader)
+	for number := 1; scanner.Scan(); number++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		line := parseTodoTxtLine(scanner.Text())
+
+		report.Rows++
+		if report.Rows > MaxImportRows {
+			return nil, ErrInvalidImport
+		}
+
+		row := todoTxtRow{}
+		invalid := func(column string, message string) {
+			report.Errors = append(report.Errors, ImportError{Row: number, Column: column, Message: message})
+		}
+
+		row.task.Name = line.Name
+		if row.task.Name == "" {
+			invalid("name", "name is required")
+		}
+
+		if line.Priority == 0 {
+			priority, ok := priorityForLevel(priorities, 0)
+			if !ok {
+				invalid("priority", "unknown priority")
+			}
+			row.task.Priority = priority
+		} else if priority, ok := priorityIds[line.Priority]; ok {
+			row.task.Priority = priority
+		} else {
+			invalid("priority", "unknown priority")
+		}
+
+		dueDate, err := parseCSVDate(line.Due)
+		if line.Due == "" {
+			invalid("due", "due date is required")
+		} else if err != nil {
+			invalid("due", "invalid date")
+		}
+		row.task.DueDate = dueDate
+
+		if line.Completed {
+			dateCompleted := time.Now()
+			if line.DateCompleted != "" {
+				dateCompleted, _ = time.Parse(time.DateOnly, line.DateCompleted)
+			}
+			row.dateCompleted = &dateCompleted
+		}
+
+		if len(line.Projects) > 1 {
+			invalid("project", "a task can only have one project")
+		} else if len(line.Projects) == 1 {
+			listId := validator.findList(line.Projects[0], matchTodoTxtName)
+			if listId == nil {
+				row.newList = line.Projects[0]
+			} else if owned, err := validator.ownsList(listId); err != nil {
+				log.Printf("Error in task-ServiceImp-ImportTodoTxt: %v", err)
+				return nil, err
+			} else if !owned {
+				invalid("project", "list is not owned by the user")
+			} else {
+				row.task.ListId = uuid.NullUUID{UUID: *listId, Valid: true}
+			}
+		}
+
+		for _, context := range line.Contexts {
+			found := false
+			for _, tag := range tags {
+				if matchTodoTxtName(tag.Name, context) {
+					row.tags = append(row.tags, tag.Id)
+					found = true
+					break
+				}
+			}
+			if !found {
+				row.newTags = append(row.newTags, context)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+	if scanner.Err() != nil {
+		return nil, importReadError(scanner.Err())
+	}
+
+	if len(report.Errors) > 0 || dryRun {
+		return report, nil
+	}
+
+	err = s.Repository.Transaction(func(tx Repository) error {
+		service := *s
+		service.Repository = tx
+
+		importRows, err := service.createTodoTxtNames(tokenString, rows)
+		if err != nil {
+			return err
+		}
+		return service.addImportedRows(tokenString, id, importRows)
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-ImportTodoTxt: %v", err)
+		return nil, err
+	}
+
+	report.Imported = len(rows)
+	return report, nil
+}
+
+// createTodoTxtNames will create the lists and tags missing for the rows of an import, each name is created once.
+func (s *ServiceImp) createTodoTxtNames(tokenString *string, rows []todoTxtRow) ([]importRow, error) {
+	lists := make(map[string]uuid.UUID)
+	tags := make(map[string]uuid.UUID)
+	importRows := make([]importRow, len(rows))
+	for i := range rows {
+		if rows[i].newList != "" {
+			key := strings.ToLower(todoTxtName(rows[i].newList))
+			listId, ok := lists[key]
+			if !ok {
+				list, err := s.AddList(tokenString, &NewList{Name: rows[i].newList, Color: importColor})
+				if err != nil {
+					return nil, err
+				}
+				listId = list.Id
+				lists[key] = listId
+			}
+			rows[i].task.ListId = uuid.NullUUID{UUID: listId, Valid: true}
+		}
+
+		for _, name := range rows[i].newTags {
+			key := strings.ToLower(todoTxtName(name))
+			tagId, ok := tags[key]
+			if !ok {
+				tag, err := s.AddTag(tokenString, &NewTag{Name: name, Color: importColor})
+				if err != nil {
+					return nil, err
+				}
+				tagId = tag.Id
+				tags[key] = tagId
+			}
+			rows[i].tags = append(rows[i].tags, tagId)
+		}
+
+		importRows[i] = rows[i].importRow
+	}
+	return importRows, nil
+}
@@ -0,0 +1,130 @@
+package task
+
+import (
+	"bytes"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestParseTodoTxtLine(t *testing.T) {
+	tests := []struct {
+		text string
+		want todoTxtLine
+	}{
+		{
+			text: "(B) Write report +Work due:2026-02-01T09:00:00Z",
+			want: todoTxtLine{Priority: 'B', Name: "Write report", Projects: []string{"Work"}, Due: "2026-02-01T09:00:00Z"},
+		},
+		{
+			text: "x 2026-01-05 Call the bank +Finance @phone @Errands due:2026-01-04 pri:A",
+			want: todoTxtLine{Completed: true, DateCompleted: "2026-01-05", Priority: 'A', Name: "Call the bank",
+				Projects: []string{"Finance"}, Contexts: []string{"phone", "Errands"}, Due: "2026-01-04"},
+		},
+		{
+			text: "2026-01-01 Plan trip rec:1w + @",
+			want: todoTxtLine{Name: "2026-01-01 Plan trip rec:1w + @"},
+		},
+		{
+			text: "(a) lower case is not a priority x",
+			want: todoTxtLine{Name: "(a) lower case is not a priority x"},
+		},
+		{
+			text: `\x \(A) \+project \\ due`,
+			want: todoTxtLine{Name: `x (A) +project \ due`},
+		},
+	}
+
+	for _, test := range tests {
+		line := parseTodoTxtLine(test.text)
+		if !reflect.DeepEqual(*line, test.want) {
+			t.Errorf("parseTodoTxtLine(%q) = %+v, want %+v", test.text, *line, test.want)
+		}
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	due := time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC)
+	completed := NullTime{sql.NullTime{Time: time.Date(2026, time.January, 5, 15, 0, 0, 0, time.UTC), Valid: true}}
+	listId := uuid.New()
+	listNames := map[uuid.UUID]string{listId: "Home Office"}
+	letters := map[int64]byte{1: 'A', 2: 'B'}
+
+	tests := []struct {
+		name string
+		task Task
+	}{
+		{"plain", Task{Name: "Write report", Priority: 1, DueDate: due}},
+		{"completed", Task{Name: "Call the bank", Priority: 2, DueDate: due.Add(9 * time.Hour), DateCompleted: completed}},
+		{"list and tags", Task{Name: "Buy paper", Priority: 1, DueDate: due, ListId: listId, Tags: []Tag{{Name: "shop run"}}}},
+		{"project and context words", Task{Name: "Email +team about @lunch", Priority: 1, DueDate: due}},
+		{"extension words", Task{Name: "Move due:friday to pri:A", Priority: 1, DueDate: due}},
+		{"completion mark", Task{Name: "x marks the spot", Priority: 3, DueDate: due}},
+		{"priority", Task{Name: "(C) is not a priority", Priority: 3, DueDate: due}},
+		{"priority after completion", Task{Name: "(C) done", Priority: 1, DueDate: due, DateCompleted: completed}},
+		{"backslashes", Task{Name: `\x and \+ and \`, Priority: 1, DueDate: due}},
+		{"other words", Task{Name: "x + @ a:b 2026-01-01 later x", Priority: 1, DueDate: due}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			err := writeTodoTxt(&buffer, []Task{test.task}, listNames, letters)
+			if err != nil {
+				t.Fatalf("writeTodoTxt returned %v", err)
+			}
+			text := strings.TrimSuffix(buffer.String(), "\n")
+			line := parseTodoTxtLine(text)
+
+			if line.Name != test.task.Name {
+				t.Errorf("name of %q = %q, want %q", text, line.Name, test.task.Name)
+			}
+			if line.Completed != test.task.DateCompleted.Valid {
+				t.Errorf("completed of %q = %t, want %t", text, line.Completed, test.task.DateCompleted.Valid)
+			}
+			if line.Priority != letters[test.task.Priority] {
+				t.Errorf("priority of %q = %q, want %q", text, line.Priority, letters[test.task.Priority])
+			}
+			if line.Due != formatTodoTxtDate(test.task.DueDate) {
+				t.Errorf("due date of %q = %q, want %q", text, line.Due, formatTodoTxtDate(test.task.DueDate))
+			}
+
+			var projects []string
+			if name, ok := listNames[test.task.ListId]; ok {
+				projects = []string{todoTxtName(name)}
+			}
+			if !reflect.DeepEqual(line.Projects, projects) {
+				t.Errorf("projects of %q = %v, want %v", text, line.Projects, projects)
+			}
+			var contexts []string
+			for _, tag := range test.task.Tags {
+				contexts = append(contexts, todoTxtName(tag.Name))
+			}
+			if !reflect.DeepEqual(line.Contexts, contexts) {
+				t.Errorf("contexts of %q = %v, want %v", text, line.Contexts, contexts)
+			}
+		})
+	}
+}
+
+func TestFormatTodoTxtDate(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2026, time.February, 1, 0, 0, 0, 0, time.UTC), "2026-02-01"},
+		{time.Date(2026, time.February, 1, 9, 0, 0, 0, time.UTC), "2026-02-01T09:00:00Z"},
+		{time.Date(2026, time.February, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)), "2026-02-01"},
+	}
+
+	for _, test := range tests {
+		got := formatTodoTxtDate(test.date)
+		if got != test.want {
+			t.Errorf("formatTodoTxtDate(%v) = %q, want %q", test.date, got, test.want)
+		}
+	}
+}
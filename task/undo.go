@@ -0,0 +1,64 @@
+package task
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UndoHeader is the response header holding the undo token of a change.
+const UndoHeader = "Undo-Token"
+
+// Undo is a change of a task that can be reverted until it expires.
+// Before and After are the task family, as returned by GetTaskFamily, before and after the change.
+type Undo struct {
+	Id uuid.UUID
+	// UserId is the user that made the change, only they can revert it.
+	UserId      uuid.UUID
+	OwnerId     uuid.UUID
+	TaskId      uuid.UUID
+	Before      []Task
+	After       []Task
+	DateExpires time.Time
+}
+
+// checkUndo will check that none of the tasks touched by a change was changed or permanently deleted since.
+func checkUndo(undo *Undo, current []Task) error {
+	after := make(map[uuid.UUID]int64, len(undo.After))
+	for _, task := range undo.After {
+		after[task.Id] = task.Version
+	}
+
+	for _, task := range current {
+		version, ok := after[task.Id]
+		if !ok || version != task.Version {
+			return ErrUndoConflict
+		}
+	}
+
+	if len(current) != len(undo.After) {
+		return ErrUndoConflict
+	}
+	return nil
+}
+
+// parentsFirst will order tasks so that every task comes after its parent if the parent is one of the tasks.
+func parentsFirst(tasks []Task) []Task {
+	pending := make(map[uuid.UUID]bool, len(tasks))
+	for _, task := range tasks {
+		pending[task.Id] = true
+	}
+
+	ordered := make([]Task, 0, len(tasks))
+	for added := true; added; {
+		added = false
+		for _, task := range tasks {
+			if pending[task.Id] && (!task.ParentId.Valid || !pending[task.ParentId.UUID]) {
+				ordered = append(ordered, task)
+				pending[task.Id] = false
+				added = true
+			}
+		}
+	}
+	return ordered
+}
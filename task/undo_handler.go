@@ -0,0 +1,63 @@
+package task
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+)
+
+// setUndoToken will add the undo token of a change to the response, changes that changed nothing have none.
+func setUndoToken(w http.ResponseWriter, undoToken string) {
+	if undoToken != "" {
+		w.Header().Set(UndoHeader, undoToken)
+	}
+}
+
+// handleUndoNotFound will respond each time an undo token is unknown, expired or already used.
+func (h *HandlerImp) handleUndoNotFound(w http.ResponseWriter) {
+	http.Error(w, "Undo not found", http.StatusNotFound)
+}
+
+// handleUndoConflict will respond each time a task was changed again after the change being undone.
+func (h *HandlerImp) handleUndoConflict(w http.ResponseWriter) {
+	http.Error(w, "Task changed since", http.StatusConflict)
+}
+
+// HandleUndo will handle post requests reverting the change of the undo token in the query.
+// It responds with the task and the token that reverts the undo, a task the undo removed is sent from the trash.
+func (h *HandlerImp) HandleUndo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	task, redoToken, err := h.Service.Undo(&token, r.URL.Query().Get("token"))
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if errors.Is(err, ErrUndoNotFound) {
+		h.handleUndoNotFound(w)
+		return
+	} else if errors.Is(err, ErrUndoConflict) {
+		h.handleUndoConflict(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleUndo: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	setUndoToken(w, redoToken)
+	if task == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	h.writeTask(w, task)
+}
@@ -0,0 +1,139 @@
+package task
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// AddUndo will add a change that can be reverted until it expires.
+func (r *PostgresRepository) AddUndo(undo *Undo) error {
+	before, err := json.Marshal(undo.Before)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddUndo: %v", err)
+		return err
+	}
+
+	after, err := json.Marshal(undo.After)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddUndo: %v", err)
+		return err
+	}
+
+	query := "INSERT INTO task_undos(id, user_id, owner_id, task_id, before, after, date_expires) VALUES ($1, $2, $3, $4, $5, $6, $7)"
+	log.Printf("Executing query in task-PostgresRepository-AddUndo: %s | Parameters %s, %s, %s, %s, %s", query, undo.Id, undo.UserId, undo.OwnerId, undo.TaskId, undo.DateExpires)
+
+	_, err = r.database.Exec(query, undo.Id, undo.UserId, undo.OwnerId, undo.TaskId, before, after, undo.DateExpires)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-AddUndo: %v", err)
+	}
+	return err
+}
+
+// TakeUndo will delete and return a change made by a user that has not expired at a date.
+// It returns ErrUndoNotFound if there is no such change, so every change is reverted at most once.
+func (r *PostgresRepository) TakeUndo(undoId *uuid.UUID, userId *uuid.UUID, now *time.Time) (*Undo, error) {
+	query := "DELETE FROM task_undos WHERE id = $1 AND user_id = $2 AND date_expires > $3 " +
+		"RETURNING id, user_id, owner_id, task_id, before, after, date_expires"
+	log.Printf("Executing query in task-PostgresRepository-TakeUndo: %s | Parameters %s, %s, %s", query, undoId, userId, now)
+
+	var undo Undo
+	var before []byte
+	var after []byte
+	err := r.database.QueryRow(query, *undoId, *userId, *now).Scan(&undo.Id, &undo.UserId, &undo.OwnerId, &undo.TaskId, &before, &after, &undo.DateExpires)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUndoNotFound
+	} else if err != nil {
+		log.Printf("Error in task-PostgresRepository-TakeUndo: %v", err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(before, &undo.Before)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-TakeUndo: %v", err)
+		return nil, err
+	}
+
+	err = json.Unmarshal(after, &undo.After)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-TakeUndo: %v", err)
+		return nil, err
+	}
+	return &undo, nil
+}
+
+// DeleteExpiredUndos will delete the changes that expired before a date and return how many were deleted.
+func (r *PostgresRepository) DeleteExpiredUndos(before *time.Time) (int64, error) {
+	query := "DELETE FROM task_undos WHERE date_expires <= $1"
+	log.Printf("Executing query in task-PostgresRepository-DeleteExpiredUndos: %s | Parameters %s", query, before)
+
+	result, err := r.database.Exec(query, *before)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteExpiredUndos: %v", err)
+		return 0, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-DeleteExpiredUndos: %v", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// ReplaceTask will write every stored column and the tags of a task owned by a user.
+// A task that no longer exists is added again with its id.
+func (r *PostgresRepository) ReplaceTask(task *Task, userId *uuid.UUID) error {
+	query := "INSERT INTO tasks(id, name, description, priority, due_date, date_completed, date_deleted, version, user_id, parent_id, list_id, rank, recurrence_rule, series_id, recurrence_date, recurrence_index, " +
+		"estimate_value, estimate_unit) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18) ON CONFLICT (id) DO UPDATE SET " +
+		"name = EXCLUDED.name, description = EXCLUDED.description, priority = EXCLUDED.priority, due_date = EXCLUDED.due_date, " +
+		"date_completed = EXCLUDED.date_completed, date_deleted = EXCLUDED.date_deleted, version = EXCLUDED.version, " +
+		"parent_id = EXCLUDED.parent_id, list_id = EXCLUDED.list_id, rank = EXCLUDED.rank, recurrence_rule = EXCLUDED.recurrence_rule, " +
+		"series_id = EXCLUDED.series_id, recurrence_date = EXCLUDED.recurrence_date, recurrence_index = EXCLUDED.recurrence_index, " +
+		"estimate_value = EXCLUDED.estimate_value, estimate_unit = EXCLUDED.estimate_unit " +
+		"WHERE tasks.user_id = EXCLUDED.user_id"
+	args := append([]any{task.Id, task.Name, task.Description, task.Priority, task.DueDate, task.DateCompleted, task.DateDeleted, task.Version, *userId, task.ParentId, task.ListId, task.Rank}, append(task.Recurrence.values(), task.Estimate.values()...)...)
+	log.Printf("Executing query in task-PostgresRepository-ReplaceTask: %s | Parameters %v", query, args)
+
+	_, err := r.database.Exec(query, args...)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-ReplaceTask: %v", err)
+		return err
+	}
+
+	query = "DELETE FROM task_tags WHERE task_id = (SELECT id FROM tasks WHERE id = $1 AND user_id = $2)"
+	log.Printf("Executing query in task-PostgresRepository-ReplaceTask: %s | Parameters %s, %s", query, task.Id, userId)
+
+	_, err = r.database.Exec(query, task.Id, *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-ReplaceTask: %v", err)
+		return err
+	}
+
+	if len(task.Tags) == 0 {
+		return nil
+	}
+
+	tagIds := make([]uuid.UUID, len(task.Tags))
+	for i, tag := range task.Tags {
+		tagIds[i] = tag.Id
+	}
+	return r.AttachTags(&task.Id, userId, tagIds)
+}
+
+// TrashTasks will move tasks owned by a user that are not in the trash yet to the trash.
+func (r *PostgresRepository) TrashTasks(taskIds []uuid.UUID, userId *uuid.UUID, dateDeleted *time.Time) error {
+	query := "UPDATE tasks SET date_deleted = $1, version = version + 1 WHERE id = ANY($2::uuid[]) AND user_id = $3 AND date_deleted IS NULL"
+	log.Printf("Executing query in task-PostgresRepository-TrashTasks: %s | Parameters %s, %v, %s", query, dateDeleted, taskIds, userId)
+
+	_, err := r.database.Exec(query, *dateDeleted, pq.Array(uuidStrings(taskIds)), *userId)
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-TrashTasks: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package task
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Undo will revert the change of an undo token made by the user, a token can be used once before it expires.
+// Tasks the change added are moved to the trash. A change can not be reverted once a task it touched was permanently
+// deleted by emptying the trash or by the purge, as the comments, shares and other rows of the task are gone with it.
+// It returns the task the change was made on, which is in the trash if the undo removed it, and the token that reverts the undo.
+func (s *ServiceImp) Undo(tokenString *string, undoToken string) (*Task, string, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-Undo: %v", err)
+		return nil, "", ErrInvalidToken
+	}
+
+	undoId, err := uuid.Parse(undoToken)
+	if err != nil {
+		return nil, "", ErrUndoNotFound
+	}
+
+	var task *Task
+	var redoToken string
+	err = s.Repository.Transaction(func(tx Repository) error {
+		service := *s
+		service.Repository = tx
+
+		now := time.Now().UTC()
+		undo, err := tx.TakeUndo(&undoId, id, &now)
+		if err != nil {
+			return err
+		}
+
+		redoToken, err = service.recordChanges(id, &undo.OwnerId, &undo.TaskId, func(service *ServiceImp) error {
+			return service.revert(undo)
+		})
+		if err != nil {
+			return err
+		}
+
+		family, err := tx.GetTaskFamily(&undo.TaskId, &undo.OwnerId)
+		if err != nil {
+			return err
+		}
+		for i := range family {
+			if family[i].Id == undo.TaskId {
+				task = &family[i]
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-Undo: %v", err)
+		return nil, "", err
+	}
+	return task, redoToken, nil
+}
+
+// revert will put the tasks touched by a change back to their state before it.
+// It returns ErrUndoConflict if any of them changed after the change.
+func (s *ServiceImp) revert(undo *Undo) error {
+	current, err := s.Repository.GetTaskFamily(&undo.TaskId, &undo.OwnerId)
+	if err != nil {
+		return err
+	}
+
+	err = checkUndo(undo, current)
+	if err != nil {
+		return err
+	}
+
+	before := make(map[uuid.UUID]bool, len(undo.Before))
+	for _, task := range undo.Before {
+		before[task.Id] = true
+	}
+
+	added := make([]uuid.UUID, 0)
+	for _, task := range current {
+		if !before[task.Id] {
+			added = append(added, task.Id)
+		}
+	}
+	if len(added) > 0 {
+		// the added tasks go to the trash instead of being deleted, so a redo keeps what was attached to them since
+		dateDeleted := time.Now().UTC()
+		err = s.Repository.TrashTasks(added, &undo.OwnerId, &dateDeleted)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The reverted tasks get a version after the one of the change, so clients holding the changed task see a new version.
+	versions := make(map[uuid.UUID]int64, len(undo.After))
+	for _, task := range undo.After {
+		versions[task.Id] = task.Version + 1
+	}
+
+	for _, task := range parentsFirst(undo.Before) {
+		if version, ok := versions[task.Id]; ok {
+			task.Version = version
+		}
+
+		err = s.Repository.ReplaceTask(&task, &undo.OwnerId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
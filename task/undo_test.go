@@ -0,0 +1,75 @@
+package task
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckUndo(t *testing.T) {
+	a := Task{Id: uuid.New(), Version: 2}
+	b := Task{Id: uuid.New(), Version: 5}
+	undo := &Undo{After: []Task{a, b}}
+
+	changedB := b
+	changedB.Version++
+
+	tests := []struct {
+		name    string
+		current []Task
+		want    error
+	}{
+		{"unchanged", []Task{a, b}, nil},
+		{"permanently deleted since", []Task{a}, ErrUndoConflict},
+		{"nothing left", nil, ErrUndoConflict},
+		{"changed since", []Task{a, changedB}, ErrUndoConflict},
+		{"added since", []Task{a, b, {Id: uuid.New(), Version: 1}}, ErrUndoConflict},
+	}
+
+	for _, test := range tests {
+		err := checkUndo(undo, test.current)
+		if !errors.Is(err, test.want) {
+			t.Errorf("checkUndo(%s) returned %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestParentsFirst(t *testing.T) {
+	root := subtask("root", nil, false)
+	a := subtask("a", &root, false)
+	a1 := subtask("a1", &a, false)
+	b := subtask("b", &root, false)
+	orphan := subtask("orphan", &Task{Id: uuid.New()}, false)
+
+	tests := []struct {
+		name  string
+		tasks []Task
+	}{
+		{"already ordered", []Task{root, a, a1, b}},
+		{"children first", []Task{a1, a, b, root}},
+		{"mixed", []Task{b, a1, root, a}},
+		{"parent not among the tasks", []Task{a1, orphan, a}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ordered := parentsFirst(test.tasks)
+			if len(ordered) != len(test.tasks) {
+				t.Fatalf("parentsFirst returned %d tasks, want %d", len(ordered), len(test.tasks))
+			}
+
+			seen := make(map[uuid.UUID]bool)
+			given := make(map[uuid.UUID]bool)
+			for _, task := range test.tasks {
+				given[task.Id] = true
+			}
+			for _, task := range ordered {
+				if task.ParentId.Valid && given[task.ParentId.UUID] && !seen[task.ParentId.UUID] {
+					t.Errorf("%s comes before its parent", task.Name)
+				}
+				seen[task.Id] = true
+			}
+		})
+	}
+}
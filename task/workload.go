@@ -0,0 +1,93 @@
+package task
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Capacity is the effort a user can take on in a day, a zero field is not checked.
+type Capacity struct {
+	Minutes int64 `json:"minutes"`
+	Points  int64 `json:"points"`
+}
+
+// Load is the summed estimates of a set of open tasks, tasks without an estimate are only counted.
+type Load struct {
+	Minutes     int64 `json:"minutes"`
+	Points      int64 `json:"points"`
+	Tasks       int64 `json:"tasks"`
+	Unestimated int64 `json:"unestimated"`
+}
+
+// DueLoad is the load of the open tasks due on a day, Day is null for the tasks that are overdue.
+type DueLoad struct {
+	Day sql.NullTime
+	Load
+}
+
+// WorkloadDay is the load of the open tasks due on a day of the current week.
+type WorkloadDay struct {
+	// Date is the day in the requested time zone formatted as YYYY-MM-DD.
+	Date string `json:"date"`
+	Load
+	OverCapacity bool `json:"overCapacity"`
+}
+
+// Workload is the load of the open tasks of a user by due date.
+// Week covers today until the end of the ISO week and Days splits it by day.
+type Workload struct {
+	Overdue  Load          `json:"overdue"`
+	Today    Load          `json:"today"`
+	Week     Load          `json:"week"`
+	Days     []WorkloadDay `json:"days"`
+	Capacity Capacity      `json:"capacity"`
+	// OverCommitted is true if any day of the week is over capacity.
+	OverCommitted bool `json:"overCommitted"`
+}
+
+// add will add another load to a load.
+func (l *Load) add(other Load) {
+	l.Minutes += other.Minutes
+	l.Points += other.Points
+	l.Tasks += other.Tasks
+	l.Unestimated += other.Unestimated
+}
+
+// exceededBy will check if a load is more than the capacity in any unit that has a limit.
+func (c Capacity) exceededBy(load Load) bool {
+	return c.Minutes > 0 && load.Minutes > c.Minutes || c.Points > 0 && load.Points > c.Points
+}
+
+// weekEnd will return the start of the Monday after a day, the day is the start of a day in its time zone.
+func weekEnd(today time.Time) time.Time {
+	days := (8 - int(today.Weekday())) % 7
+	if days == 0 {
+		days = 7
+	}
+	return time.Date(today.Year(), today.Month(), today.Day()+days, 0, 0, 0, 0, today.Location())
+}
+
+// buildWorkload will put the loads of the days returned by GetDueLoads into buckets and check them against a capacity.
+func buildWorkload(loads []DueLoad, today time.Time, capacity Capacity) *Workload {
+	byDate := make(map[string]Load)
+	workload := &Workload{Days: make([]WorkloadDay, 0, 7), Capacity: capacity}
+	for _, load := range loads {
+		if !load.Day.Valid {
+			workload.Overdue.add(load.Load)
+			continue
+		}
+		// The day has no time zone, its clock is already in the requested one.
+		byDate[load.Day.Time.Format(time.DateOnly)] = load.Load
+	}
+
+	end := weekEnd(today)
+	for day := today; day.Before(end); day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, day.Location()) {
+		date := day.Format(time.DateOnly)
+		workloadDay := WorkloadDay{Date: date, Load: byDate[date], OverCapacity: capacity.exceededBy(byDate[date])}
+		workload.Days = append(workload.Days, workloadDay)
+		workload.Week.add(workloadDay.Load)
+		workload.OverCommitted = workload.OverCommitted || workloadDay.OverCapacity
+	}
+	workload.Today = workload.Days[0].Load
+	return workload
+}
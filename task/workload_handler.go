@@ -0,0 +1,51 @@
+package task
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+	"time"
+)
+
+// HandleGetWorkload will handle get requests for the estimates of the open tasks due this week.
+// The optional tz query parameter is the time zone days are counted in, UTC is used when it is missing.
+func (h *HandlerImp) HandleGetWorkload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	location := time.UTC
+	if value := r.URL.Query().Get("tz"); value != "" {
+		location, err = time.LoadLocation(value)
+		if err != nil {
+			h.handleInvalidFilter(w)
+			return
+		}
+	}
+
+	workload, err := h.Service.GetWorkload(&token, location)
+	if errors.Is(err, ErrInvalidToken) {
+		h.handleInvalidToken(w)
+		return
+	} else if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetWorkload: %v", err)
+		h.handleServerError(w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err = json.NewEncoder(w).Encode(workload)
+	if err != nil {
+		log.Printf("Error in task-HandlerImp-HandleGetWorkload: %v", err)
+	}
+}
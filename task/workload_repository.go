@@ -0,0 +1,45 @@
+package task
+
+import (
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetDueLoads will sum the estimates of the open tasks owned by a user due before a time, by the day they are due in a time zone.
+// The tasks due before the start of today are summed into a single row without a day. Tasks of archived lists are left out.
+func (r *PostgresRepository) GetDueLoads(userId *uuid.UUID, today time.Time, end time.Time, location *time.Location) ([]DueLoad, error) {
+	query := "SELECT CASE WHEN due_date < $2 THEN NULL ELSE date_trunc('day', due_date AT TIME ZONE $4) END, " +
+		"COALESCE(SUM(estimate_value) FILTER (WHERE estimate_unit = 'minutes'), 0), " +
+		"COALESCE(SUM(estimate_value) FILTER (WHERE estimate_unit = 'points'), 0), " +
+		"COUNT(*), COUNT(*) FILTER (WHERE estimate_value IS NULL) FROM tasks " +
+		"WHERE user_id = $1 AND due_date < $3 AND date_completed IS NULL AND date_deleted IS NULL " +
+		"AND list_id NOT IN (SELECT id FROM lists WHERE archived = true) GROUP BY 1"
+	log.Printf("Executing query in task-PostgresRepository-GetDueLoads: %s | Parameters %s, %s, %s, %s", query, userId, today, end, location)
+
+	rows, err := r.database.Query(query, *userId, today, end, location.String())
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetDueLoads: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	loads := make([]DueLoad, 0)
+	for rows.Next() {
+		var load DueLoad
+		err := rows.Scan(&load.Day, &load.Minutes, &load.Points, &load.Tasks, &load.Unestimated)
+		if err != nil {
+			log.Printf("Error in task-PostgresRepository-GetDueLoads: %v", err)
+			return nil, err
+		}
+		loads = append(loads, load)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in task-PostgresRepository-GetDueLoads: %v", err)
+		return nil, err
+	}
+	return loads, nil
+}
@@ -0,0 +1,25 @@
+package task
+
+import (
+	"log"
+	"time"
+)
+
+// GetWorkload will return the estimates of the open tasks of a user that are overdue or due this week.
+// Days and weeks are counted in a time zone and each day of the week is checked against the capacity of the service.
+func (s *ServiceImp) GetWorkload(tokenString *string, location *time.Location) (*Workload, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetWorkload: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	loads, err := s.Repository.GetDueLoads(id, today, weekEnd(today), location)
+	if err != nil {
+		log.Printf("Error in task-ServiceImp-GetWorkload: %v", err)
+		return nil, err
+	}
+	return buildWorkload(loads, today, s.Capacity), nil
+}
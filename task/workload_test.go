@@ -0,0 +1,90 @@
+package task
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestWeekEnd(t *testing.T) {
+	zone := time.FixedZone("UTC+9", 9*3600)
+	day := func(d int, location *time.Location) time.Time {
+		return time.Date(2026, time.March, d, 0, 0, 0, 0, location)
+	}
+
+	tests := []struct {
+		name  string
+		today time.Time
+		want  time.Time
+	}{
+		{"monday", day(2, time.UTC), day(9, time.UTC)},
+		{"wednesday", day(4, time.UTC), day(9, time.UTC)},
+		{"saturday", day(7, time.UTC), day(9, time.UTC)},
+		{"sunday", day(8, time.UTC), day(9, time.UTC)},
+		{"across months", time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, time.April, 6, 0, 0, 0, 0, time.UTC)},
+		{"in a time zone", day(4, zone), day(9, zone)},
+	}
+
+	for _, test := range tests {
+		got := weekEnd(test.today)
+		if !got.Equal(test.want) || got.Location() != test.want.Location() {
+			t.Errorf("weekEnd(%s) = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestBuildWorkload(t *testing.T) {
+	// Friday, so the week has three days left
+	today := time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)
+	due := func(day int, load Load) DueLoad {
+		return DueLoad{Day: sql.NullTime{Time: time.Date(2026, time.March, day, 0, 0, 0, 0, time.UTC), Valid: true}, Load: load}
+	}
+
+	loads := []DueLoad{
+		{Load: Load{Minutes: 30, Tasks: 2, Unestimated: 1}},
+		due(6, Load{Minutes: 300, Points: 3, Tasks: 3}),
+		due(8, Load{Minutes: 600, Tasks: 2}),
+		due(9, Load{Minutes: 60, Tasks: 1}),
+	}
+	capacity := Capacity{Minutes: 480}
+
+	workload := buildWorkload(loads, today, capacity)
+
+	want := &Workload{
+		Overdue: Load{Minutes: 30, Tasks: 2, Unestimated: 1},
+		Today:   Load{Minutes: 300, Points: 3, Tasks: 3},
+		Week:    Load{Minutes: 900, Points: 3, Tasks: 5},
+		Days: []WorkloadDay{
+			{Date: "2026-03-06", Load: Load{Minutes: 300, Points: 3, Tasks: 3}},
+			{Date: "2026-03-07"},
+			{Date: "2026-03-08", Load: Load{Minutes: 600, Tasks: 2}, OverCapacity: true},
+		},
+		Capacity:      capacity,
+		OverCommitted: true,
+	}
+	if !reflect.DeepEqual(workload, want) {
+		t.Errorf("buildWorkload = %+v, want %+v", workload, want)
+	}
+}
+
+func TestCapacityExceededBy(t *testing.T) {
+	tests := []struct {
+		capacity Capacity
+		load     Load
+		want     bool
+	}{
+		{Capacity{}, Load{Minutes: 10000, Points: 100}, false},
+		{Capacity{Minutes: 480}, Load{Minutes: 480}, false},
+		{Capacity{Minutes: 480}, Load{Minutes: 481}, true},
+		{Capacity{Points: 5}, Load{Minutes: 10000, Points: 5}, false},
+		{Capacity{Minutes: 480, Points: 5}, Load{Points: 6}, true},
+	}
+
+	for _, test := range tests {
+		got := test.capacity.exceededBy(test.load)
+		if got != test.want {
+			t.Errorf("%+v.exceededBy(%+v) = %t, want %t", test.capacity, test.load, got, test.want)
+		}
+	}
+}
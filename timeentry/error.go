@@ -0,0 +1,12 @@
+package timeentry
+
+import "errors"
+
+var (
+	ErrInvalidToken    = errors.New("invalid token")
+	ErrInvalidEntry    = errors.New("invalid entry")
+	ErrInvalidFilter   = errors.New("invalid filter")
+	ErrEntryNotFound   = errors.New("entry not found")
+	ErrTimerNotRunning = errors.New("timer not running")
+	ErrTaskNotFound    = errors.New("task not found")
+)
@@ -0,0 +1,300 @@
+package timeentry
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"task-server/middleware"
+
+	"github.com/google/uuid"
+)
+
+// HandlerImp implements Handler.
+type HandlerImp struct {
+	Service Service
+}
+
+// handleInvalidMethod will respond each time a request uses the wrong method.
+func (h *HandlerImp) handleInvalidMethod(w http.ResponseWriter) {
+	http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+}
+
+// handleInvalidJson will respond to any invalid json formats send to the server.
+func (h *HandlerImp) handleInvalidJson(w http.ResponseWriter) {
+	http.Error(w, "Invalid json format", http.StatusBadRequest)
+}
+
+// handleServerError will respond each time there is a server error.
+func (h *HandlerImp) handleServerError(w http.ResponseWriter) {
+	http.Error(w, "Internal server error", http.StatusInternalServerError)
+}
+
+// handleInvalidToken will respond each time there is an invalid token.
+func (h *HandlerImp) handleInvalidToken(w http.ResponseWriter) {
+	http.Error(w, "Invalid token", http.StatusUnauthorized)
+}
+
+// handleInvalidId will respond each time an id is not a valid uuid.
+func (h *HandlerImp) handleInvalidId(w http.ResponseWriter) {
+	http.Error(w, "Invalid id", http.StatusBadRequest)
+}
+
+// handleError will respond to the errors returned by the service.
+func (h *HandlerImp) handleError(w http.ResponseWriter, err error, source string) {
+	switch {
+	case errors.Is(err, ErrInvalidEntry):
+		http.Error(w, "Invalid entry", http.StatusBadRequest)
+	case errors.Is(err, ErrInvalidFilter):
+		http.Error(w, "Invalid filter", http.StatusBadRequest)
+	case errors.Is(err, ErrEntryNotFound):
+		http.Error(w, "Entry not found", http.StatusNotFound)
+	case errors.Is(err, ErrTimerNotRunning):
+		http.Error(w, "Timer not running", http.StatusNotFound)
+	case errors.Is(err, ErrTaskNotFound):
+		http.Error(w, "Task not found", http.StatusNotFound)
+	case errors.Is(err, ErrInvalidToken):
+		h.handleInvalidToken(w)
+	default:
+		log.Printf("Error in timeentry-HandlerImp-%s: %v", source, err)
+		h.handleServerError(w)
+	}
+}
+
+// writeJson will send a value as json.
+func (h *HandlerImp) writeJson(w http.ResponseWriter, value any, source string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		log.Printf("Error in timeentry-HandlerImp-%s: %v", source, err)
+	}
+}
+
+// HandleGet will handle get requests and send the entries of the user matching the task, from and to query parameters.
+func (h *HandlerImp) HandleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	filter, err := ParseFilter(r.URL.Query())
+	if err != nil {
+		h.handleError(w, err, "HandleGet")
+		return
+	}
+
+	entries, err := h.Service.GetEntries(&token, filter)
+	if err != nil {
+		h.handleError(w, err, "HandleGet")
+		return
+	}
+
+	h.writeJson(w, entries, "HandleGet")
+}
+
+// HandlePost will handle post requests for adding a manual entry.
+func (h *HandlerImp) HandlePost(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedEntry NewEntry
+	err = json.NewDecoder(r.Body).Decode(&receivedEntry)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	entry, err := h.Service.AddEntry(&token, &receivedEntry)
+	if err != nil {
+		h.handleError(w, err, "HandlePost")
+		return
+	}
+
+	h.writeJson(w, entry, "HandlePost")
+}
+
+// HandlePut will handle put requests for editing an entry.
+func (h *HandlerImp) HandlePut(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var update EntryUpdate
+	err = json.NewDecoder(r.Body).Decode(&update)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	entry, err := h.Service.UpdateEntry(&token, &update)
+	if err != nil {
+		h.handleError(w, err, "HandlePut")
+		return
+	}
+
+	h.writeJson(w, entry, "HandlePut")
+}
+
+// HandleDelete will handle delete requests for deleting an entry.
+func (h *HandlerImp) HandleDelete(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	id, err := uuid.Parse(r.URL.Query().Get("id"))
+	if err != nil {
+		h.handleInvalidId(w)
+		return
+	}
+
+	err = h.Service.DeleteEntry(&token, &id)
+	if err != nil {
+		h.handleError(w, err, "HandleDelete")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleGetTimer will handle get requests and send the running timer of the user.
+func (h *HandlerImp) HandleGetTimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	entry, err := h.Service.GetRunningTimer(&token)
+	if err != nil {
+		h.handleError(w, err, "HandleGetTimer")
+		return
+	}
+
+	h.writeJson(w, entry, "HandleGetTimer")
+}
+
+// HandleStartTimer will handle post requests starting a timer on a task.
+func (h *HandlerImp) HandleStartTimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	var receivedTimer NewTimer
+	err = json.NewDecoder(r.Body).Decode(&receivedTimer)
+	if err != nil {
+		h.handleInvalidJson(w)
+		return
+	}
+
+	entry, err := h.Service.StartTimer(&token, &receivedTimer)
+	if err != nil {
+		h.handleError(w, err, "HandleStartTimer")
+		return
+	}
+
+	h.writeJson(w, entry, "HandleStartTimer")
+}
+
+// HandleStopTimer will handle post requests stopping the running timer and send the finished entry.
+func (h *HandlerImp) HandleStopTimer(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	entry, err := h.Service.StopTimer(&token)
+	if err != nil {
+		h.handleError(w, err, "HandleStopTimer")
+		return
+	}
+
+	h.writeJson(w, entry, "HandleStopTimer")
+}
+
+// HandleGetTotals will handle get requests and send the time spent grouped by the by query parameter,
+// which is task, day or week. The entries are filtered like in HandleGet and days are counted in the tz time zone.
+func (h *HandlerImp) HandleGetTotals(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.handleInvalidMethod(w)
+		return
+	}
+
+	token, err := middleware.GetTokenFromHeader(r)
+	if err != nil {
+		h.handleInvalidToken(w)
+		return
+	}
+
+	filter, err := ParseFilter(r.URL.Query())
+	if err != nil {
+		h.handleError(w, err, "HandleGetTotals")
+		return
+	}
+
+	groupBy := GroupBy(r.URL.Query().Get("by"))
+	if groupBy == "" {
+		groupBy = GroupTask
+	}
+
+	totals, err := h.Service.GetTotals(&token, filter, groupBy)
+	if err != nil {
+		h.handleError(w, err, "HandleGetTotals")
+		return
+	}
+
+	h.writeJson(w, totals, "HandleGetTotals")
+}
+
+// NewHandlerImp will create a new handler with a service.
+func NewHandlerImp(service Service) *HandlerImp {
+	return &HandlerImp{
+		Service: service,
+	}
+}
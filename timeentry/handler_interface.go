@@ -0,0 +1,30 @@
+package timeentry
+
+import "net/http"
+
+// Handler defines methods for a time entry handler.
+type Handler interface {
+	// HandleGet will handle getting the time entries of the user.
+	HandleGet(w http.ResponseWriter, r *http.Request)
+
+	// HandlePost will handle adding a manual time entry.
+	HandlePost(w http.ResponseWriter, r *http.Request)
+
+	// HandlePut will handle editing a time entry.
+	HandlePut(w http.ResponseWriter, r *http.Request)
+
+	// HandleDelete will handle deleting a time entry.
+	HandleDelete(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetTimer will handle getting the running timer.
+	HandleGetTimer(w http.ResponseWriter, r *http.Request)
+
+	// HandleStartTimer will handle starting a timer on a task.
+	HandleStartTimer(w http.ResponseWriter, r *http.Request)
+
+	// HandleStopTimer will handle stopping the running timer.
+	HandleStopTimer(w http.ResponseWriter, r *http.Request)
+
+	// HandleGetTotals will handle getting the time spent per task, day or week.
+	HandleGetTotals(w http.ResponseWriter, r *http.Request)
+}
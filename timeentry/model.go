@@ -0,0 +1,109 @@
+package timeentry
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxNoteLength is the biggest number of characters the note of an entry can have.
+const MaxNoteLength = 1000
+
+// GroupBy is how time entries are summed into totals.
+type GroupBy string
+
+const (
+	GroupTask GroupBy = "task"
+	GroupDay  GroupBy = "day"
+	// GroupWeek sums the entries of ISO weeks, which start on Monday.
+	GroupWeek GroupBy = "week"
+)
+
+// Entry is a period of time a user spent on a task.
+type Entry struct {
+	Id        uuid.UUID `json:"id"`
+	TaskId    uuid.UUID `json:"taskId"`
+	DateStart time.Time `json:"dateStart"`
+	// DateEnd is nil while the entry is the running timer of the user.
+	DateEnd *time.Time `json:"dateEnd"`
+	Note    string     `json:"note"`
+}
+
+// NewTimer is the body of a request starting a timer on a task.
+type NewTimer struct {
+	TaskId uuid.UUID `json:"taskId"`
+	Note   string    `json:"note"`
+}
+
+// NewEntry is a manual entry that will be added to a task.
+type NewEntry struct {
+	TaskId    uuid.UUID `json:"taskId"`
+	DateStart time.Time `json:"dateStart"`
+	DateEnd   time.Time `json:"dateEnd"`
+	Note      string    `json:"note"`
+}
+
+// EntryUpdate is the body of a request editing an entry, DateEnd can only be nil for the running timer.
+type EntryUpdate struct {
+	Id        uuid.UUID  `json:"id"`
+	DateStart time.Time  `json:"dateStart"`
+	DateEnd   *time.Time `json:"dateEnd"`
+	Note      string     `json:"note"`
+}
+
+// Filter holds the options used when listing or summing entries, the bounds apply to the start of the entries.
+type Filter struct {
+	TaskId *uuid.UUID
+	From   *time.Time
+	To     *time.Time
+	// Location is the time zone days and weeks are counted in.
+	Location *time.Location
+}
+
+// Total is the time spent on a task or in a day or week, running timers count until now.
+type Total struct {
+	TaskId  *uuid.UUID `json:"taskId,omitempty"`
+	Period  *time.Time `json:"period,omitempty"`
+	Seconds int64      `json:"seconds"`
+}
+
+// ParseFilter will read a filter from the query parameters task, from, to and tz.
+// The time zone defaults to UTC.
+func ParseFilter(values url.Values) (*Filter, error) {
+	filter := &Filter{Location: time.UTC}
+
+	if value := values.Get("task"); value != "" {
+		taskId, err := uuid.Parse(value)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.TaskId = &taskId
+	}
+
+	if value := values.Get("from"); value != "" {
+		from, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.From = &from
+	}
+
+	if value := values.Get("to"); value != "" {
+		to, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.To = &to
+	}
+
+	if value := values.Get("tz"); value != "" {
+		location, err := time.LoadLocation(value)
+		if err != nil {
+			return nil, ErrInvalidFilter
+		}
+		filter.Location = location
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,293 @@
+package timeentry
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PostgresRepository is an implementation of Repository.
+type PostgresRepository struct {
+	database *sql.DB
+}
+
+// entryColumns are the columns selected for every entry.
+const entryColumns = "id, task_id, date_start, date_end, note"
+
+// rowScanner is implemented by both sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanEntry will scan a row selected with entryColumns.
+func scanEntry(row rowScanner) (*Entry, error) {
+	var entry Entry
+	err := row.Scan(&entry.Id, &entry.TaskId, &entry.DateStart, &entry.DateEnd, &entry.Note)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// buildEntryConditions will build the where conditions and arguments for a filter on the entries of a user.
+func buildEntryConditions(userId *uuid.UUID, filter *Filter) ([]string, []any) {
+	conditions := []string{"user_id = $1"}
+	args := []any{*userId}
+
+	addArg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.TaskId != nil {
+		conditions = append(conditions, "task_id = "+addArg(*filter.TaskId))
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "date_start >= "+addArg(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "date_start < "+addArg(*filter.To))
+	}
+
+	return conditions, args
+}
+
+// GetEntries will get the entries of a user matching a filter, the latest first.
+func (r *PostgresRepository) GetEntries(userId *uuid.UUID, filter *Filter) ([]Entry, error) {
+	conditions, args := buildEntryConditions(userId, filter)
+	query := "SELECT " + entryColumns + " FROM time_entries WHERE " + strings.Join(conditions, " AND ") + " ORDER BY date_start DESC, id"
+	log.Printf("Executing query in timeentry-PostgresRepository-GetEntries: %s | Parameters %v", query, args)
+
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-GetEntries: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]Entry, 0)
+	for rows.Next() {
+		entry, err := scanEntry(rows)
+		if err != nil {
+			log.Printf("Error in timeentry-PostgresRepository-GetEntries: %v", err)
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-GetEntries: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetEntry will get an entry of a user.
+func (r *PostgresRepository) GetEntry(entryId *uuid.UUID, userId *uuid.UUID) (*Entry, error) {
+	query := "SELECT " + entryColumns + " FROM time_entries WHERE id = $1 AND user_id = $2"
+	log.Printf("Executing query in timeentry-PostgresRepository-GetEntry: %s | Parameters %s, %s", query, entryId, userId)
+
+	entry, err := scanEntry(r.database.QueryRow(query, *entryId, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEntryNotFound
+	} else if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-GetEntry: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetRunningEntry will get the running timer of a user.
+func (r *PostgresRepository) GetRunningEntry(userId *uuid.UUID) (*Entry, error) {
+	query := "SELECT " + entryColumns + " FROM time_entries WHERE user_id = $1 AND date_end IS NULL"
+	log.Printf("Executing query in timeentry-PostgresRepository-GetRunningEntry: %s | Parameters %s", query, userId)
+
+	entry, err := scanEntry(r.database.QueryRow(query, *userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTimerNotRunning
+	} else if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-GetRunningEntry: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// StartTimer will stop the running timer of a user, if any, and add an entry without an end.
+// The row of the user is locked first so concurrent starts can not leave two timers running.
+func (r *PostgresRepository) StartTimer(entry *Entry, userId *uuid.UUID) error {
+	log.Printf("Executing query in timeentry-PostgresRepository-StartTimer: BEGIN")
+	tx, err := r.database.Begin()
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-StartTimer: %v", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	query := "SELECT id FROM users WHERE id = $1 FOR UPDATE"
+	log.Printf("Executing query in timeentry-PostgresRepository-StartTimer: %s | Parameters %s", query, userId)
+	_, err = tx.Exec(query, *userId)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-StartTimer: %v", err)
+		return err
+	}
+
+	query = "UPDATE time_entries SET date_end = $2 WHERE user_id = $1 AND date_end IS NULL"
+	log.Printf("Executing query in timeentry-PostgresRepository-StartTimer: %s | Parameters %s, %s", query, userId, entry.DateStart)
+	_, err = tx.Exec(query, *userId, entry.DateStart)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-StartTimer: %v", err)
+		return err
+	}
+
+	query = "INSERT INTO time_entries(id, task_id, user_id, date_start, date_end, note) VALUES ($1, $2, $3, $4, NULL, $5)"
+	log.Printf("Executing query in timeentry-PostgresRepository-StartTimer: %s | Parameters %s, %s, %s, %s, %s", query,
+		entry.Id, entry.TaskId, userId, entry.DateStart, entry.Note)
+	_, err = tx.Exec(query, entry.Id, entry.TaskId, *userId, entry.DateStart, entry.Note)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-StartTimer: %v", err)
+		return err
+	}
+
+	log.Printf("Executing query in timeentry-PostgresRepository-StartTimer: COMMIT")
+	err = tx.Commit()
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-StartTimer: %v", err)
+	}
+	return err
+}
+
+// StopTimer will end the running timer of a user at a time and return it.
+func (r *PostgresRepository) StopTimer(userId *uuid.UUID, now time.Time) (*Entry, error) {
+	query := "UPDATE time_entries SET date_end = GREATEST($2, date_start) WHERE user_id = $1 AND date_end IS NULL RETURNING " + entryColumns
+	log.Printf("Executing query in timeentry-PostgresRepository-StopTimer: %s | Parameters %s, %s", query, userId, now)
+
+	entry, err := scanEntry(r.database.QueryRow(query, *userId, now))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTimerNotRunning
+	} else if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-StopTimer: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// AddEntry will add an entry of a user.
+func (r *PostgresRepository) AddEntry(entry *Entry, userId *uuid.UUID) error {
+	query := "INSERT INTO time_entries(id, task_id, user_id, date_start, date_end, note) VALUES ($1, $2, $3, $4, $5, $6)"
+	log.Printf("Executing query in timeentry-PostgresRepository-AddEntry: %s | Parameters %s, %s, %s, %s, %v, %s", query,
+		entry.Id, entry.TaskId, userId, entry.DateStart, entry.DateEnd, entry.Note)
+
+	_, err := r.database.Exec(query, entry.Id, entry.TaskId, *userId, entry.DateStart, entry.DateEnd, entry.Note)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-AddEntry: %v", err)
+	}
+	return err
+}
+
+// UpdateEntry will update the period and note of an entry of a user.
+func (r *PostgresRepository) UpdateEntry(entry *Entry, userId *uuid.UUID) error {
+	query := "UPDATE time_entries SET date_start = $3, date_end = $4, note = $5 WHERE id = $1 AND user_id = $2"
+	log.Printf("Executing query in timeentry-PostgresRepository-UpdateEntry: %s | Parameters %s, %s, %s, %v, %s", query,
+		entry.Id, userId, entry.DateStart, entry.DateEnd, entry.Note)
+
+	result, err := r.database.Exec(query, entry.Id, *userId, entry.DateStart, entry.DateEnd, entry.Note)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-UpdateEntry: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-UpdateEntry: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+// DeleteEntry will delete an entry of a user.
+func (r *PostgresRepository) DeleteEntry(entryId *uuid.UUID, userId *uuid.UUID) error {
+	query := "DELETE FROM time_entries WHERE id = $1 AND user_id = $2"
+	log.Printf("Executing query in timeentry-PostgresRepository-DeleteEntry: %s | Parameters %s, %s", query, entryId, userId)
+
+	result, err := r.database.Exec(query, *entryId, *userId)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-DeleteEntry: %v", err)
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-DeleteEntry: %v", err)
+		return err
+	}
+	if count == 0 {
+		return ErrEntryNotFound
+	}
+	return nil
+}
+
+// GetTotals will sum the entries of a user matching a filter, running timers count until a time.
+// Days and weeks are counted in the time zone of the filter and an entry counts toward the period it starts in.
+func (r *PostgresRepository) GetTotals(userId *uuid.UUID, filter *Filter, groupBy GroupBy, now time.Time) ([]Total, error) {
+	conditions, args := buildEntryConditions(userId, filter)
+	args = append(args, now)
+	seconds := fmt.Sprintf("CAST(SUM(EXTRACT(EPOCH FROM COALESCE(date_end, $%d) - date_start)) AS bigint)", len(args))
+
+	group := "task_id"
+	if groupBy != GroupTask {
+		args = append(args, filter.Location.String())
+		group = fmt.Sprintf("date_trunc('%s', date_start AT TIME ZONE $%d)", groupBy, len(args))
+	}
+
+	query := fmt.Sprintf("SELECT %s, %s FROM time_entries WHERE %s GROUP BY 1 ORDER BY 1", group, seconds, strings.Join(conditions, " AND "))
+	log.Printf("Executing query in timeentry-PostgresRepository-GetTotals: %s | Parameters %v", query, args)
+
+	rows, err := r.database.Query(query, args...)
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-GetTotals: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	totals := make([]Total, 0)
+	for rows.Next() {
+		var total Total
+		if groupBy == GroupTask {
+			var taskId uuid.UUID
+			err = rows.Scan(&taskId, &total.Seconds)
+			total.TaskId = &taskId
+		} else {
+			var period time.Time
+			err = rows.Scan(&period, &total.Seconds)
+			// The truncated time has no zone, its clock is read in the zone of the filter.
+			period = time.Date(period.Year(), period.Month(), period.Day(), 0, 0, 0, 0, filter.Location)
+			total.Period = &period
+		}
+		if err != nil {
+			log.Printf("Error in timeentry-PostgresRepository-GetTotals: %v", err)
+			return nil, err
+		}
+		totals = append(totals, total)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		log.Printf("Error in timeentry-PostgresRepository-GetTotals: %v", err)
+		return nil, err
+	}
+	return totals, nil
+}
+
+// NewPostgresRepository will create a new repository with a connection.
+func NewPostgresRepository(database *sql.DB) *PostgresRepository {
+	return &PostgresRepository{database}
+}
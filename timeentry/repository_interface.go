@@ -0,0 +1,37 @@
+package timeentry
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the methods for a time entry repository.
+type Repository interface {
+	// GetEntries will get the entries of a user matching a filter, the latest first.
+	GetEntries(*uuid.UUID, *Filter) ([]Entry, error)
+
+	// GetEntry will get an entry of a user.
+	GetEntry(*uuid.UUID, *uuid.UUID) (*Entry, error)
+
+	// GetRunningEntry will get the running timer of a user.
+	GetRunningEntry(*uuid.UUID) (*Entry, error)
+
+	// StartTimer will stop the running timer of a user, if any, and add an entry without an end.
+	StartTimer(*Entry, *uuid.UUID) error
+
+	// StopTimer will end the running timer of a user at a time and return it.
+	StopTimer(*uuid.UUID, time.Time) (*Entry, error)
+
+	// AddEntry will add an entry of a user.
+	AddEntry(*Entry, *uuid.UUID) error
+
+	// UpdateEntry will update the period and note of an entry of a user.
+	UpdateEntry(*Entry, *uuid.UUID) error
+
+	// DeleteEntry will delete an entry of a user.
+	DeleteEntry(*uuid.UUID, *uuid.UUID) error
+
+	// GetTotals will sum the entries of a user matching a filter, running timers count until a time.
+	GetTotals(*uuid.UUID, *Filter, GroupBy, time.Time) ([]Total, error)
+}
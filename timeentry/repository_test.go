@@ -0,0 +1,34 @@
+package timeentry
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestBuildEntryConditions(t *testing.T) {
+	userId, taskId := uuid.New(), uuid.New()
+	from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name       string
+		filter     Filter
+		conditions []string
+		args       []any
+	}{
+		{"user only", Filter{}, []string{"user_id = $1"}, []any{userId}},
+		{"task", Filter{TaskId: &taskId}, []string{"user_id = $1", "task_id = $2"}, []any{userId, taskId}},
+		{"period", Filter{From: &from, To: &to}, []string{"user_id = $1", "date_start >= $2", "date_start < $3"}, []any{userId, from, to}},
+		{"everything", Filter{TaskId: &taskId, To: &to}, []string{"user_id = $1", "task_id = $2", "date_start < $3"}, []any{userId, taskId, to}},
+	}
+
+	for _, test := range tests {
+		conditions, args := buildEntryConditions(&userId, &test.filter)
+		if !reflect.DeepEqual(conditions, test.conditions) || !reflect.DeepEqual(args, test.args) {
+			t.Errorf("buildEntryConditions(%s) = %v, %v, want %v, %v", test.name, conditions, args, test.conditions, test.args)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package timeentry
+
+import (
+	"errors"
+	"log"
+	"task-server/middleware"
+	"task-server/task"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskAccess is used to find the role of a user on a task.
+type TaskAccess interface {
+	// GetTaskAccess will get the owner of a task and the highest role a user has on it.
+	GetTaskAccess(*uuid.UUID, *uuid.UUID) (*uuid.UUID, task.Role, error)
+}
+
+// ServiceImp is an implementation of Service.
+type ServiceImp struct {
+	Repository    Repository
+	Tasks         TaskAccess
+	Authenticator middleware.Authenticator
+}
+
+// checkAccess will check that a user can see a task, every user the task is shared with can track time on it.
+func (s *ServiceImp) checkAccess(userId *uuid.UUID, taskId *uuid.UUID) error {
+	_, _, err := s.Tasks.GetTaskAccess(taskId, userId)
+	if errors.Is(err, task.ErrTaskNotFound) {
+		return ErrTaskNotFound
+	}
+	return err
+}
+
+// checkPeriod will check that a period starts before it ends and does not end in the future.
+// A nil end is the end of a running timer, which only needs to have started.
+func checkPeriod(start time.Time, end *time.Time, now time.Time) bool {
+	if start.IsZero() || start.After(now) {
+		return false
+	}
+	return end == nil || end.After(start) && !end.After(now)
+}
+
+// GetEntries will return the entries of the user matching a filter.
+func (s *ServiceImp) GetEntries(tokenString *string, filter *Filter) ([]Entry, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-GetEntries: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	entries, err := s.Repository.GetEntries(id, filter)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-GetEntries: %v", err)
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetRunningTimer will return the running timer of the user.
+func (s *ServiceImp) GetRunningTimer(tokenString *string) (*Entry, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-GetRunningTimer: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	entry, err := s.Repository.GetRunningEntry(id)
+	if errors.Is(err, ErrTimerNotRunning) {
+		return nil, err
+	} else if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-GetRunningTimer: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// StartTimer will start a timer on a task, the running timer of the user is stopped first.
+func (s *ServiceImp) StartTimer(tokenString *string, newTimer *NewTimer) (*Entry, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-StartTimer: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if len(newTimer.Note) > MaxNoteLength {
+		return nil, ErrInvalidEntry
+	}
+
+	err = s.checkAccess(id, &newTimer.TaskId)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-StartTimer: %v", err)
+		return nil, err
+	}
+
+	entry := &Entry{
+		Id:        uuid.New(),
+		TaskId:    newTimer.TaskId,
+		DateStart: time.Now(),
+		Note:      newTimer.Note,
+	}
+	err = s.Repository.StartTimer(entry, id)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-StartTimer: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// StopTimer will stop the running timer of the user.
+func (s *ServiceImp) StopTimer(tokenString *string) (*Entry, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-StopTimer: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	entry, err := s.Repository.StopTimer(id, time.Now())
+	if errors.Is(err, ErrTimerNotRunning) {
+		return nil, err
+	} else if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-StopTimer: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// AddEntry will add a manual entry of the user on a task.
+func (s *ServiceImp) AddEntry(tokenString *string, newEntry *NewEntry) (*Entry, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-AddEntry: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkPeriod(newEntry.DateStart, &newEntry.DateEnd, time.Now()) || len(newEntry.Note) > MaxNoteLength {
+		return nil, ErrInvalidEntry
+	}
+
+	err = s.checkAccess(id, &newEntry.TaskId)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-AddEntry: %v", err)
+		return nil, err
+	}
+
+	entry := &Entry{
+		Id:        uuid.New(),
+		TaskId:    newEntry.TaskId,
+		DateStart: newEntry.DateStart,
+		DateEnd:   &newEntry.DateEnd,
+		Note:      newEntry.Note,
+	}
+	err = s.Repository.AddEntry(entry, id)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-AddEntry: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// UpdateEntry will edit the period and note of an entry of the user.
+// Only the running timer can be left without an end, a stopped entry can not be restarted.
+func (s *ServiceImp) UpdateEntry(tokenString *string, update *EntryUpdate) (*Entry, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-UpdateEntry: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if !checkPeriod(update.DateStart, update.DateEnd, time.Now()) || len(update.Note) > MaxNoteLength {
+		return nil, ErrInvalidEntry
+	}
+
+	entry, err := s.Repository.GetEntry(&update.Id, id)
+	if errors.Is(err, ErrEntryNotFound) {
+		return nil, err
+	} else if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-UpdateEntry: %v", err)
+		return nil, err
+	}
+
+	if update.DateEnd == nil && entry.DateEnd != nil {
+		return nil, ErrInvalidEntry
+	}
+
+	entry.DateStart = update.DateStart
+	entry.DateEnd = update.DateEnd
+	entry.Note = update.Note
+	err = s.Repository.UpdateEntry(entry, id)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-UpdateEntry: %v", err)
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteEntry will delete an entry of the user, deleting the running timer stops it without recording time.
+func (s *ServiceImp) DeleteEntry(tokenString *string, entryId *uuid.UUID) error {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-DeleteEntry: %v", err)
+		return ErrInvalidToken
+	}
+
+	err = s.Repository.DeleteEntry(entryId, id)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-DeleteEntry: %v", err)
+		return err
+	}
+	return nil
+}
+
+// GetTotals will return the time the user spent per task, day or week on the entries matching a filter.
+func (s *ServiceImp) GetTotals(tokenString *string, filter *Filter, groupBy GroupBy) ([]Total, error) {
+	id, err := s.Authenticator.CheckAccessToken(tokenString)
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-GetTotals: %v", err)
+		return nil, ErrInvalidToken
+	}
+
+	if groupBy != GroupTask && groupBy != GroupDay && groupBy != GroupWeek {
+		return nil, ErrInvalidFilter
+	}
+
+	totals, err := s.Repository.GetTotals(id, filter, groupBy, time.Now())
+	if err != nil {
+		log.Printf("Error in timeentry-ServiceImp-GetTotals: %v", err)
+		return nil, err
+	}
+	return totals, nil
+}
+
+// NewServiceImp will create a new service with a repository, task access and authenticator.
+func NewServiceImp(repository Repository, tasks TaskAccess, authenticator middleware.Authenticator) *ServiceImp {
+	return &ServiceImp{
+		Repository:    repository,
+		Tasks:         tasks,
+		Authenticator: authenticator,
+	}
+}
@@ -0,0 +1,30 @@
+package timeentry
+
+import "github.com/google/uuid"
+
+// Service defines the methods for a time entry service.
+type Service interface {
+	// GetEntries will return the entries of the user matching a filter.
+	GetEntries(*string, *Filter) ([]Entry, error)
+
+	// GetRunningTimer will return the running timer of the user.
+	GetRunningTimer(*string) (*Entry, error)
+
+	// StartTimer will start a timer on a task, stopping the running timer of the user.
+	StartTimer(*string, *NewTimer) (*Entry, error)
+
+	// StopTimer will stop the running timer of the user.
+	StopTimer(*string) (*Entry, error)
+
+	// AddEntry will add a manual entry of the user on a task.
+	AddEntry(*string, *NewEntry) (*Entry, error)
+
+	// UpdateEntry will edit an entry of the user.
+	UpdateEntry(*string, *EntryUpdate) (*Entry, error)
+
+	// DeleteEntry will delete an entry of the user.
+	DeleteEntry(*string, *uuid.UUID) error
+
+	// GetTotals will return the time the user spent per task, day or week.
+	GetTotals(*string, *Filter, GroupBy) ([]Total, error)
+}
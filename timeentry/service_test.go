@@ -0,0 +1,34 @@
+package timeentry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckPeriod(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	at := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name  string
+		start time.Time
+		end   *time.Time
+		want  bool
+	}{
+		{"finished", now.Add(-2 * time.Hour), at(now.Add(-time.Hour)), true},
+		{"ends now", now.Add(-time.Hour), at(now), true},
+		{"running", now.Add(-time.Hour), nil, true},
+		{"starts now", now, nil, true},
+		{"no start", time.Time{}, at(now), false},
+		{"starts in the future", now.Add(time.Minute), nil, false},
+		{"ends in the future", now.Add(-time.Hour), at(now.Add(time.Minute)), false},
+		{"ends at its start", now.Add(-time.Hour), at(now.Add(-time.Hour)), false},
+		{"ends before its start", now.Add(-time.Hour), at(now.Add(-2 * time.Hour)), false},
+	}
+
+	for _, test := range tests {
+		if got := checkPeriod(test.start, test.end, now); got != test.want {
+			t.Errorf("checkPeriod(%s) = %t, want %t", test.name, got, test.want)
+		}
+	}
+}